@@ -0,0 +1,118 @@
+package grist
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// Access lists the users having access to an org, workspace or document.
+// source: https://support.getgrist.com/api/#tag/workspaces/operation/listWorkspaceAccess
+type Access struct {
+	// MaxInheritedRole caps the roles inherited from the parent resource, it
+	// is always AccessRoleNone for orgs
+	MaxInheritedRole AccessRole `json:"maxInheritedRole"`
+	Users            []User     `json:"users"`
+}
+
+// AccessDelta describes access changes, a nil role removes the user access.
+// source: https://support.getgrist.com/api/#tag/workspaces/operation/modifyWorkspaceAccess
+type AccessDelta struct {
+	// MaxInheritedRole changes the roles inherited from the parent resource,
+	// use AccessRoleNone to stop inheritance. Not available on orgs.
+	MaxInheritedRole *AccessRole            `json:"maxInheritedRole,omitempty"`
+	Users            map[string]*AccessRole `json:"users,omitempty"`
+}
+
+func pathOrg(orgID int64) string {
+	return "/orgs/" + strconv.FormatInt(orgID, 10)
+}
+
+func pathAccess(resourcePath string) string {
+	return resourcePath + "/access"
+}
+
+func getAccess(c *Client, resourcePath string) (*Access, error) {
+	endpoint := buildURL(c.ApiEndpoint(), pathAccess(resourcePath))
+	resp, err := c.GetRequest(
+		endpoint,
+		withAuth(c.ApiKey),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var access Access
+	if err := handleJSONResponse(resp, &access, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &access, nil
+}
+
+func modifyAccess(c *Client, resourcePath string, delta AccessDelta) error {
+	if delta.MaxInheritedRole == nil && len(delta.Users) == 0 {
+		return fmt.Errorf("access delta cannot be empty")
+	}
+	for email, role := range delta.Users {
+		if email == "" {
+			return fmt.Errorf("access delta: email cannot be empty")
+		}
+		if role != nil && (*role == AccessRoleNone || !role.valid()) {
+			return fmt.Errorf("access delta: invalid role %q for %s", string(*role), email)
+		}
+	}
+
+	endpoint := buildURL(c.ApiEndpoint(), pathAccess(resourcePath))
+	jsonBody, err := withJSONBody(struct {
+		Delta AccessDelta `json:"delta"`
+	}{Delta: delta})
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.PatchRequest(
+		endpoint,
+		withAuth(c.ApiKey),
+		jsonBody,
+	)
+	if err != nil {
+		return err
+	}
+	return handleStatus(resp, http.StatusOK)
+}
+
+// GetAccess lists the users having access to the org.
+// source: https://support.getgrist.com/api/#tag/orgs/operation/listOrgAccess
+func (o *Org) GetAccess(c *Client) (*Access, error) {
+	return getAccess(c, pathOrg(o.ID))
+}
+
+// ModifyAccess changes the access of users to the org, a nil role removes the user.
+// source: https://support.getgrist.com/api/#tag/orgs/operation/modifyOrgAccess
+func (o *Org) ModifyAccess(c *Client, users map[string]*AccessRole) error {
+	return modifyAccess(c, pathOrg(o.ID), AccessDelta{Users: users})
+}
+
+// GetAccess lists the users having access to the workspace.
+// source: https://support.getgrist.com/api/#tag/workspaces/operation/listWorkspaceAccess
+func (ws *Workspace) GetAccess(c *Client) (*Access, error) {
+	return getAccess(c, pathWorkspace(ws.ID))
+}
+
+// ModifyAccess changes the access of users to the workspace.
+// source: https://support.getgrist.com/api/#tag/workspaces/operation/modifyWorkspaceAccess
+func (ws *Workspace) ModifyAccess(c *Client, delta AccessDelta) error {
+	return modifyAccess(c, pathWorkspace(ws.ID), delta)
+}
+
+// GetAccess lists the users having access to the document.
+// source: https://support.getgrist.com/api/#tag/docs/operation/listDocAccess
+func (d *Doc) GetAccess(c *Client) (*Access, error) {
+	return getAccess(c, pathDescribeDocs(d.ID))
+}
+
+// ModifyAccess changes the access of users to the document.
+// source: https://support.getgrist.com/api/#tag/docs/operation/modifyDocAccess
+func (d *Doc) ModifyAccess(c *Client, delta AccessDelta) error {
+	return modifyAccess(c, pathDescribeDocs(d.ID), delta)
+}
@@ -13,20 +13,49 @@ const (
 	AccessRoleOwner  AccessRole = "owners"
 	AccessRoleEditor AccessRole = "editors"
 	AccessRoleViewer AccessRole = "viewers"
+	// AccessRoleMember is the role of org members without access to the org resources
+	AccessRoleMember AccessRole = "members"
+	// AccessRoleGuest is the role of users with access to some resources of an org only
+	AccessRoleGuest AccessRole = "guests"
+	// AccessRoleNone is encoded as null: no access, or no inherited access for maxInheritedRole
+	AccessRoleNone AccessRole = ""
 )
 
+func (r AccessRole) valid() bool {
+	switch r {
+	case AccessRoleOwner, AccessRoleEditor, AccessRoleViewer, AccessRoleMember, AccessRoleGuest, AccessRoleNone:
+		return true
+	default:
+		return false
+	}
+}
+
+// MarshalJSON encodes AccessRoleNone as null
+func (r AccessRole) MarshalJSON() ([]byte, error) {
+	if !r.valid() {
+		return nil, fmt.Errorf("invalid AccessRole: %s", string(r))
+	}
+	if r == AccessRoleNone {
+		return []byte("null"), nil
+	}
+	return json.Marshal(string(r))
+}
+
 // UnmarshalJSON allows parsing JSON into AccessRole with validation
 func (r *AccessRole) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*r = AccessRoleNone
+		return nil
+	}
+
 	var s string
 	if err := json.Unmarshal(data, &s); err != nil {
 		return err
 	}
 	role := AccessRole(s)
-	switch role {
-	case AccessRoleOwner, AccessRoleEditor, AccessRoleViewer:
-		*r = role
-		return nil
-	default:
+	if role == AccessRoleNone || !role.valid() {
 		return fmt.Errorf("invalid AccessRole: %s", s)
 	}
+	*r = role
+	return nil
 }
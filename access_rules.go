@@ -0,0 +1,541 @@
+package grist
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Metadata tables holding the access rules of a document
+const (
+	tableACLResources = "_grist_ACLResources"
+	tableACLRules     = "_grist_ACLRules"
+)
+
+// Special values of ACLResource fields
+const (
+	// ACLAllTables is the table ID of default rules, applying to every table
+	ACLAllTables = "*"
+	// ACLSpecialRules is the table ID of special rules, whose ColIDs name the rule (e.g. "FullCopies")
+	ACLSpecialRules = "*SPECIAL"
+	// ACLAllColumns is the ColIDs of rules applying to every column of a table
+	ACLAllColumns = "*"
+)
+
+// Permission strings
+const (
+	PermissionsAll  = "all"
+	PermissionsNone = "none"
+)
+
+// ACLResource is the part of the document a rule applies to
+type ACLResource struct {
+	TableID string `json:"tableId"`
+	// ColIDs is ACLAllColumns or a comma separated list of column IDs
+	ColIDs string `json:"colIds"`
+}
+
+// Validate checks the resource is complete
+func (r ACLResource) Validate() error {
+	if r.TableID == "" {
+		return fmt.Errorf("resource table id cannot be empty")
+	}
+	if r.ColIDs == "" {
+		return fmt.Errorf("resource %s: column ids cannot be empty", r.TableID)
+	}
+	return nil
+}
+
+func (r ACLResource) String() string {
+	return r.TableID + ":" + r.ColIDs
+}
+
+// ACLRule is a rule of _grist_ACLRules. Rules of a resource are evaluated by
+// increasing RulePos, the first rule whose formula matches sets the permissions.
+// source: https://support.getgrist.com/access-rules/
+type ACLRule struct {
+	// ID is the row ID of the rule, 0 for rules not saved yet
+	ID       int         `json:"id,omitempty"`
+	Resource ACLResource `json:"resource"`
+	// ACLFormula is a Python-like condition (e.g. "user.Access != OWNER"), empty to always match
+	ACLFormula string `json:"aclFormula"`
+	// PermissionsText grants (+) or denies (-) the permissions C, R, U, D and S, e.g. "+R-CUD"
+	PermissionsText string  `json:"permissionsText"`
+	Memo            string  `json:"memo,omitempty"`
+	RulePos         float64 `json:"rulePos,omitempty"`
+}
+
+// Validate checks the resource and permissions of the rule
+func (r ACLRule) Validate() error {
+	if err := r.Resource.Validate(); err != nil {
+		return err
+	}
+	if err := ValidatePermissions(r.PermissionsText); err != nil {
+		return fmt.Errorf("rule on %s: %w", r.Resource, err)
+	}
+	return nil
+}
+
+// UserAttribute adds a property to the user variable of rule formulas, looked
+// up in a table, e.g. user.Team from the Teams row whose Email is user.Email.
+type UserAttribute struct {
+	// ID is the row ID of the underlying rule, 0 for attributes not saved yet
+	ID int `json:"id,omitempty"`
+	// Name is the property added to user
+	Name string `json:"name"`
+	// TableID and LookupColID select the row matching CharID
+	TableID     string `json:"tableId"`
+	LookupColID string `json:"lookupColId"`
+	// CharID is the user property to look up, e.g. "Email"
+	CharID  string  `json:"charId"`
+	RulePos float64 `json:"rulePos,omitempty"`
+}
+
+// Validate checks the attribute is complete
+func (a UserAttribute) Validate() error {
+	if a.Name == "" || a.TableID == "" || a.LookupColID == "" || a.CharID == "" {
+		return fmt.Errorf("user attribute %q: name, table id, lookup column id and char id are required", a.Name)
+	}
+	return nil
+}
+
+// AccessRules holds the granular access rules of a document
+type AccessRules struct {
+	Rules          []ACLRule       `json:"rules"`
+	UserAttributes []UserAttribute `json:"userAttributes,omitempty"`
+}
+
+// Validate checks every rule and user attribute
+func (r *AccessRules) Validate() error {
+	for _, rule := range r.Rules {
+		if err := rule.Validate(); err != nil {
+			return err
+		}
+	}
+	for _, attr := range r.UserAttributes {
+		if err := attr.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AccessRulesDiff lists the changes needed to go from the saved rules to the wanted ones.
+// Updated and Removed entries carry the row ID of the saved rule.
+type AccessRulesDiff struct {
+	Added   AccessRules `json:"added"`
+	Updated AccessRules `json:"updated"`
+	Removed AccessRules `json:"removed"`
+}
+
+// IsEmpty reports whether the rules are already up to date
+func (d *AccessRulesDiff) IsEmpty() bool {
+	for _, r := range []AccessRules{d.Added, d.Updated, d.Removed} {
+		if len(r.Rules) > 0 || len(r.UserAttributes) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// ValidatePermissions checks a permissions string such as "all", "none",
+// "+R" or "+R-CUD". Each of the permissions C, R, U, D and S may appear once.
+func ValidatePermissions(s string) error {
+	if s == PermissionsAll || s == PermissionsNone {
+		return nil
+	}
+	if s == "" {
+		return fmt.Errorf("permissions cannot be empty")
+	}
+
+	seen := make(map[rune]bool)
+	var sign rune
+	letters := 0
+	for _, ch := range s {
+		switch ch {
+		case '+', '-':
+			if sign != 0 && letters == 0 {
+				return fmt.Errorf("invalid permissions %q: %c without permission", s, sign)
+			}
+			sign, letters = ch, 0
+		case 'C', 'R', 'U', 'D', 'S':
+			if sign == 0 {
+				return fmt.Errorf("invalid permissions %q: must start with + or -", s)
+			}
+			if seen[ch] {
+				return fmt.Errorf("invalid permissions %q: %c set twice", s, ch)
+			}
+			seen[ch] = true
+			letters++
+		default:
+			return fmt.Errorf("invalid permissions %q: unknown permission %q", s, ch)
+		}
+	}
+	if letters == 0 {
+		return fmt.Errorf("invalid permissions %q: %c without permission", s, sign)
+	}
+	return nil
+}
+
+// aclRow is a row of _grist_ACLRules, user attributes are rules with a JSON userAttributes field
+type aclRow struct {
+	ID              int
+	Resource        ACLResource
+	ACLFormula      string
+	PermissionsText string
+	Memo            string
+	RulePos         float64
+	UserAttributes  string
+}
+
+// key identifies a row by its content, for rules saved in another document
+func (r aclRow) key() string {
+	return strings.Join([]string{r.Resource.String(), r.ACLFormula, r.PermissionsText, r.Memo, r.UserAttributes}, "\x00")
+}
+
+func (r aclRow) equal(o aclRow) bool {
+	return r.key() == o.key() && r.RulePos == o.RulePos
+}
+
+// userAttributesJSON is the userAttributes field of a rule
+type userAttributesJSON struct {
+	Name        string `json:"name"`
+	TableID     string `json:"tableId"`
+	LookupColID string `json:"lookupColId"`
+	CharID      string `json:"charId"`
+}
+
+// userAttributesResource is the resource Grist attaches user attributes to
+var userAttributesResource = ACLResource{TableID: ACLAllTables, ColIDs: ACLAllColumns}
+
+// rows flattens the rules, rules and attributes without position are placed after the others
+func (r *AccessRules) rows() ([]aclRow, error) {
+	var maxPos float64
+	for _, rule := range r.Rules {
+		maxPos = max(maxPos, rule.RulePos)
+	}
+	for _, attr := range r.UserAttributes {
+		maxPos = max(maxPos, attr.RulePos)
+	}
+	nextPos := func(pos float64) float64 {
+		if pos != 0 {
+			return pos
+		}
+		maxPos++
+		return maxPos
+	}
+
+	rows := make([]aclRow, 0, len(r.UserAttributes)+len(r.Rules))
+	for _, attr := range r.UserAttributes {
+		b, err := json.Marshal(userAttributesJSON{attr.Name, attr.TableID, attr.LookupColID, attr.CharID})
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, aclRow{
+			ID:             attr.ID,
+			Resource:       userAttributesResource,
+			RulePos:        nextPos(attr.RulePos),
+			UserAttributes: string(b),
+		})
+	}
+	for _, rule := range r.Rules {
+		rows = append(rows, aclRow{
+			ID:              rule.ID,
+			Resource:        rule.Resource,
+			ACLFormula:      rule.ACLFormula,
+			PermissionsText: rule.PermissionsText,
+			Memo:            rule.Memo,
+			RulePos:         nextPos(rule.RulePos),
+		})
+	}
+	return rows, nil
+}
+
+func (r *AccessRules) addRow(row aclRow) error {
+	if row.UserAttributes == "" {
+		r.Rules = append(r.Rules, ACLRule{
+			ID:              row.ID,
+			Resource:        row.Resource,
+			ACLFormula:      row.ACLFormula,
+			PermissionsText: row.PermissionsText,
+			Memo:            row.Memo,
+			RulePos:         row.RulePos,
+		})
+		return nil
+	}
+
+	var attr userAttributesJSON
+	if err := json.Unmarshal([]byte(row.UserAttributes), &attr); err != nil {
+		return fmt.Errorf("rule %d: invalid user attributes: %w", row.ID, err)
+	}
+	r.UserAttributes = append(r.UserAttributes, UserAttribute{
+		ID:          row.ID,
+		Name:        attr.Name,
+		TableID:     attr.TableID,
+		LookupColID: attr.LookupColID,
+		CharID:      attr.CharID,
+		RulePos:     row.RulePos,
+	})
+	return nil
+}
+
+// savedACL is the content of the ACL metadata tables
+type savedACL struct {
+	resources map[int]ACLResource
+	rows      []aclRow
+}
+
+func (d *Doc) loadACL(c *Client) (*savedACL, error) {
+	resources, err := d.ListRecords(c, tableACLResources, nil)
+	if err != nil {
+		return nil, err
+	}
+	rules, err := d.ListRecords(c, tableACLRules, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	saved := &savedACL{resources: make(map[int]ACLResource, len(resources.Records))}
+	for _, rec := range resources.Records {
+		saved.resources[rec.ID] = ACLResource{
+			TableID: cellString(rec.Fields["tableId"]),
+			ColIDs:  cellString(rec.Fields["colIds"]),
+		}
+	}
+	for _, rec := range rules.Records {
+		resourceID, _ := cellInt(rec.Fields["resource"])
+		resource, ok := saved.resources[resourceID]
+		if !ok || resource.TableID == "" {
+			// Every document holds a placeholder rule on an empty resource, left from the legacy ACL format
+			continue
+		}
+		row := aclRow{
+			ID:              rec.ID,
+			Resource:        resource,
+			ACLFormula:      cellString(rec.Fields["aclFormula"]),
+			PermissionsText: cellString(rec.Fields["permissionsText"]),
+			Memo:            cellString(rec.Fields["memo"]),
+			UserAttributes:  cellString(rec.Fields["userAttributes"]),
+		}
+		if pos := rec.Fields["rulePos"]; pos != nil && pos.Number != nil {
+			row.RulePos = *pos.Number
+		}
+		saved.rows = append(saved.rows, row)
+	}
+	sort.SliceStable(saved.rows, func(i, j int) bool {
+		return saved.rows[i].RulePos < saved.rows[j].RulePos
+	})
+	return saved, nil
+}
+
+// LoadAccessRules reads the access rules of the document, sorted by position
+func (d *Doc) LoadAccessRules(c *Client) (*AccessRules, error) {
+	saved, err := d.loadACL(c)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := &AccessRules{}
+	for _, row := range saved.rows {
+		if err := rules.addRow(row); err != nil {
+			return nil, err
+		}
+	}
+	return rules, nil
+}
+
+// DiffAccessRules compares the saved access rules of the document with rules.
+// Rules are matched by ID when it is set, otherwise by content, so rules
+// exported from another document can be compared too.
+func (d *Doc) DiffAccessRules(c *Client, rules *AccessRules) (*AccessRulesDiff, error) {
+	saved, err := d.loadACL(c)
+	if err != nil {
+		return nil, err
+	}
+	added, updated, removed, err := diffACLRows(saved.rows, rules)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := &AccessRulesDiff{}
+	for _, group := range []struct {
+		rows []aclRow
+		to   *AccessRules
+	}{{added, &diff.Added}, {updated, &diff.Updated}, {removed, &diff.Removed}} {
+		for _, row := range group.rows {
+			if err := group.to.addRow(row); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return diff, nil
+}
+
+func diffACLRows(saved []aclRow, rules *AccessRules) (added, updated, removed []aclRow, err error) {
+	if err := rules.Validate(); err != nil {
+		return nil, nil, nil, err
+	}
+	wanted, err := rules.rows()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	byID := make(map[int]int, len(saved))
+	for i, row := range saved {
+		byID[row.ID] = i
+	}
+	matched := make([]bool, len(saved))
+	pairs := make(map[int]int, len(wanted))
+	for w, row := range wanted {
+		if i, ok := byID[row.ID]; ok && row.ID != 0 && !matched[i] {
+			matched[i] = true
+			pairs[w] = i
+		}
+	}
+	for w, row := range wanted {
+		if _, ok := pairs[w]; ok {
+			continue
+		}
+		for i, s := range saved {
+			if !matched[i] && s.key() == row.key() {
+				matched[i] = true
+				pairs[w] = i
+				break
+			}
+		}
+	}
+
+	for w, row := range wanted {
+		i, ok := pairs[w]
+		if !ok {
+			row.ID = 0
+			added = append(added, row)
+			continue
+		}
+		row.ID = saved[i].ID
+		if !row.equal(saved[i]) {
+			updated = append(updated, row)
+		}
+	}
+	for i, row := range saved {
+		if !matched[i] {
+			removed = append(removed, row)
+		}
+	}
+	return added, updated, removed, nil
+}
+
+// SaveAccessRules replaces the access rules of the document with rules, in a
+// single bundle of user actions. It returns nil when nothing changed.
+func (d *Doc) SaveAccessRules(c *Client, rules *AccessRules) (*ApplyResult, error) {
+	saved, err := d.loadACL(c)
+	if err != nil {
+		return nil, err
+	}
+	added, updated, removed, err := diffACLRows(saved.rows, rules)
+	if err != nil {
+		return nil, err
+	}
+	if len(added)+len(updated)+len(removed) == 0 {
+		return nil, nil
+	}
+
+	actions := NewUserActions()
+	resourceIDs := make(map[ACLResource]int, len(saved.resources))
+	for id, resource := range saved.resources {
+		if prev, ok := resourceIDs[resource]; !ok || id < prev {
+			resourceIDs[resource] = id
+		}
+	}
+
+	// New resources get negative row IDs, which Grist resolves within the bundle
+	var newIDs []int
+	newResources := map[string][]*CellValue{}
+	for _, row := range append(added, updated...) {
+		if _, ok := resourceIDs[row.Resource]; ok {
+			continue
+		}
+		id := -(len(newIDs) + 1)
+		resourceIDs[row.Resource] = id
+		newIDs = append(newIDs, id)
+		newResources["tableId"] = append(newResources["tableId"], NewStringCell(row.Resource.TableID))
+		newResources["colIds"] = append(newResources["colIds"], NewStringCell(row.Resource.ColIDs))
+	}
+	if len(newIDs) > 0 {
+		actions.Raw("BulkAddRecord", tableACLResources, newIDs, newResources)
+	}
+
+	if len(removed) > 0 {
+		ids := make([]int, 0, len(removed))
+		for _, row := range removed {
+			ids = append(ids, row.ID)
+		}
+		actions.BulkRemoveRecord(tableACLRules, ids)
+	}
+	if len(updated) > 0 {
+		ids := make([]int, 0, len(updated))
+		for _, row := range updated {
+			ids = append(ids, row.ID)
+		}
+		actions.BulkUpdateRecord(tableACLRules, ids, aclColumns(updated, resourceIDs))
+	}
+	if len(added) > 0 {
+		actions.BulkAddRecord(tableACLRules, aclColumns(added, resourceIDs))
+	}
+
+	// Drop the resources left without rules
+	changed := make(map[int]bool, len(removed)+len(updated))
+	for _, row := range append(removed, updated...) {
+		changed[row.ID] = true
+	}
+	used := make(map[ACLResource]bool)
+	for _, row := range saved.rows {
+		if !changed[row.ID] {
+			used[row.Resource] = true
+		}
+	}
+	for _, row := range append(added, updated...) {
+		used[row.Resource] = true
+	}
+	var unused []int
+	for _, row := range saved.rows {
+		if id := resourceIDs[row.Resource]; changed[row.ID] && !used[row.Resource] && !slices.Contains(unused, id) {
+			unused = append(unused, id)
+		}
+	}
+	if len(unused) > 0 {
+		slices.Sort(unused)
+		actions.BulkRemoveRecord(tableACLResources, unused)
+	}
+
+	return d.ApplyUserActions(c, actions)
+}
+
+func aclColumns(rows []aclRow, resourceIDs map[ACLResource]int) map[string][]*CellValue {
+	columns := map[string][]*CellValue{}
+	for _, row := range rows {
+		columns["resource"] = append(columns["resource"], NewNumberCell(float64(resourceIDs[row.Resource])))
+		columns["aclFormula"] = append(columns["aclFormula"], NewStringCell(row.ACLFormula))
+		columns["permissionsText"] = append(columns["permissionsText"], NewStringCell(row.PermissionsText))
+		columns["memo"] = append(columns["memo"], NewStringCell(row.Memo))
+		columns["rulePos"] = append(columns["rulePos"], NewNumberCell(row.RulePos))
+		columns["userAttributes"] = append(columns["userAttributes"], NewStringCell(row.UserAttributes))
+	}
+	return columns
+}
+
+func cellString(c *CellValue) string {
+	if c == nil || c.String == nil {
+		return ""
+	}
+	return *c.String
+}
+
+func cellInt(c *CellValue) (int, bool) {
+	if c == nil || c.Number == nil {
+		return 0, false
+	}
+	return rowIDFromValue(*c.Number)
+}
@@ -0,0 +1,176 @@
+package grist
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidatePermissions(t *testing.T) {
+	for _, s := range []string{"all", "none", "+R", "-CUDS", "+R-CUD", "+CRUDS", "+R+U-D"} {
+		assert.NoError(t, ValidatePermissions(s), s)
+	}
+	for _, s := range []string{"", "R", "+", "+R-", "+RR", "+R-R", "+X", "ALL", "+r"} {
+		assert.Error(t, ValidatePermissions(s), s)
+	}
+}
+
+// aclServer serves the ACL metadata tables and records the applied actions
+func aclServer(t *testing.T, applied *[]UserAction) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/docs/doc1/tables/_grist_ACLResources/records":
+			w.Write([]byte(`{"records": [
+				{"id": 1, "fields": {"tableId": "", "colIds": ""}},
+				{"id": 2, "fields": {"tableId": "*", "colIds": "*"}},
+				{"id": 3, "fields": {"tableId": "Pets", "colIds": "*"}}
+			]}`))
+		case "/api/docs/doc1/tables/_grist_ACLRules/records":
+			w.Write([]byte(`{"records": [
+				{"id": 1, "fields": {"resource": 1, "aclFormula": "", "permissionsText": "", "rulePos": 0}},
+				{"id": 3, "fields": {"resource": 3, "aclFormula": "user.Access != OWNER", "permissionsText": "-D", "memo": "", "rulePos": 2, "userAttributes": ""}},
+				{"id": 2, "fields": {"resource": 2, "aclFormula": "", "permissionsText": "", "memo": "", "rulePos": 1,
+					"userAttributes": "{\"name\":\"Team\",\"tableId\":\"Teams\",\"lookupColId\":\"Email\",\"charId\":\"Email\"}"}},
+				{"id": 4, "fields": {"resource": 2, "aclFormula": "user.Access == VIEWER", "permissionsText": "+R-CUD", "memo": "viewers", "rulePos": 3, "userAttributes": ""}}
+			]}`))
+		case "/api/docs/doc1/apply":
+			assert.Equal(t, http.MethodPost, r.Method)
+			body, _ := io.ReadAll(r.Body)
+			if err := json.Unmarshal(body, applied); err != nil {
+				t.Fatalf("Expected actions, got %v", err)
+			}
+			w.Write([]byte(`{"actionNum": 7, "actionHash": "h", "retValues": []}`))
+		default:
+			t.Errorf("Unexpected request %s", r.URL.Path)
+		}
+	}))
+}
+
+func TestDoc_LoadAccessRules(t *testing.T) {
+	server := aclServer(t, nil)
+	defer server.Close()
+
+	client, _ := NewGristClient(context.Background(), server.URL, "valid-key")
+	doc := &Doc{ID: "doc1"}
+	rules, err := doc.LoadAccessRules(client)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	assert.Equal(t, []UserAttribute{
+		{ID: 2, Name: "Team", TableID: "Teams", LookupColID: "Email", CharID: "Email", RulePos: 1},
+	}, rules.UserAttributes)
+	assert.Equal(t, []ACLRule{
+		{ID: 3, Resource: ACLResource{TableID: "Pets", ColIDs: "*"}, ACLFormula: "user.Access != OWNER", PermissionsText: "-D", RulePos: 2},
+		{ID: 4, Resource: ACLResource{TableID: "*", ColIDs: "*"}, ACLFormula: "user.Access == VIEWER", PermissionsText: "+R-CUD", Memo: "viewers", RulePos: 3},
+	}, rules.Rules)
+}
+
+func TestDoc_DiffAccessRules(t *testing.T) {
+	server := aclServer(t, nil)
+	defer server.Close()
+
+	client, _ := NewGristClient(context.Background(), server.URL, "valid-key")
+	doc := &Doc{ID: "doc1"}
+
+	t.Run("With loaded rules is empty", func(t *testing.T) {
+		rules, _ := doc.LoadAccessRules(client)
+		diff, err := doc.DiffAccessRules(client, rules)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		assert.True(t, diff.IsEmpty())
+	})
+	t.Run("Matches rules without ID by content", func(t *testing.T) {
+		diff, err := doc.DiffAccessRules(client, &AccessRules{
+			Rules: []ACLRule{
+				{Resource: ACLResource{TableID: "Pets", ColIDs: "*"}, ACLFormula: "user.Access != OWNER", PermissionsText: "-D", RulePos: 2},
+				{Resource: ACLResource{TableID: "Pets", ColIDs: "Name"}, ACLFormula: "", PermissionsText: "-U"},
+			},
+			UserAttributes: []UserAttribute{
+				{Name: "Team", TableID: "Teams", LookupColID: "Email", CharID: "Email", RulePos: 5},
+			},
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		assert.Equal(t, []ACLRule{{Resource: ACLResource{TableID: "Pets", ColIDs: "Name"}, PermissionsText: "-U", RulePos: 6}}, diff.Added.Rules)
+		assert.Equal(t, []UserAttribute{{ID: 2, Name: "Team", TableID: "Teams", LookupColID: "Email", CharID: "Email", RulePos: 5}}, diff.Updated.UserAttributes)
+		assert.Empty(t, diff.Updated.Rules)
+		assert.Len(t, diff.Removed.Rules, 1)
+		assert.Equal(t, 4, diff.Removed.Rules[0].ID)
+	})
+	t.Run("With invalid permissions fails", func(t *testing.T) {
+		_, err := doc.DiffAccessRules(client, &AccessRules{
+			Rules: []ACLRule{{Resource: ACLResource{TableID: "Pets", ColIDs: "*"}, PermissionsText: "+W"}},
+		})
+		assert.Error(t, err)
+	})
+}
+
+func TestDoc_SaveAccessRules(t *testing.T) {
+	var applied []UserAction
+	server := aclServer(t, &applied)
+	defer server.Close()
+
+	client, _ := NewGristClient(context.Background(), server.URL, "valid-key")
+	doc := &Doc{ID: "doc1"}
+
+	t.Run("Applies the diff in one bundle", func(t *testing.T) {
+		rules, _ := doc.LoadAccessRules(client)
+		rules.Rules[0].PermissionsText = "-CD"
+		rules.Rules = append(rules.Rules[:1], ACLRule{
+			Resource:        ACLResource{TableID: "Pets", ColIDs: "Name"},
+			PermissionsText: "-U",
+		})
+
+		result, err := doc.SaveAccessRules(client, rules)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		assert.Equal(t, 7, result.ActionNum)
+
+		b, _ := json.Marshal(applied)
+		assert.JSONEq(t, `[
+			["BulkAddRecord", "_grist_ACLResources", [-1], {"tableId": ["Pets"], "colIds": ["Name"]}],
+			["BulkRemoveRecord", "_grist_ACLRules", [4]],
+			["BulkUpdateRecord", "_grist_ACLRules", [3], {
+				"resource": [3], "aclFormula": ["user.Access != OWNER"], "permissionsText": ["-CD"],
+				"memo": [""], "rulePos": [2], "userAttributes": [""]
+			}],
+			["BulkAddRecord", "_grist_ACLRules", [null], {
+				"resource": [-1], "aclFormula": [""], "permissionsText": ["-U"],
+				"memo": [""], "rulePos": [3], "userAttributes": [""]
+			}]
+		]`, string(b))
+	})
+	t.Run("Removes resources left without rules", func(t *testing.T) {
+		applied = nil
+		rules, _ := doc.LoadAccessRules(client)
+		rules.Rules = rules.Rules[1:]
+
+		if _, err := doc.SaveAccessRules(client, rules); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		b, _ := json.Marshal(applied)
+		assert.JSONEq(t, `[
+			["BulkRemoveRecord", "_grist_ACLRules", [3]],
+			["BulkRemoveRecord", "_grist_ACLResources", [3]]
+		]`, string(b))
+	})
+	t.Run("Without changes applies nothing", func(t *testing.T) {
+		applied = nil
+		rules, _ := doc.LoadAccessRules(client)
+		result, err := doc.SaveAccessRules(client, rules)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		assert.Nil(t, result)
+		assert.Nil(t, applied)
+	})
+}
@@ -0,0 +1,116 @@
+package grist
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccessRole_UnmarshalJSON(t *testing.T) {
+	var users []User
+	err := json.Unmarshal([]byte(`[
+		{"id":1,"name":"A","access":"members"},
+		{"id":2,"name":"B","access":"guests"},
+		{"id":3,"name":"C","access":null,"parentAccess":"editors"}
+	]`), &users)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	assert.Equal(t, AccessRoleMember, users[0].Access)
+	assert.Equal(t, AccessRoleGuest, users[1].Access)
+	assert.Equal(t, AccessRoleNone, users[2].Access)
+	assert.Equal(t, AccessRoleEditor, users[2].ParentAccess)
+
+	var role AccessRole
+	assert.EqualError(t, json.Unmarshal([]byte(`"admins"`), &role), "invalid AccessRole: admins")
+}
+
+func TestDoc_ModifyAccess(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPatch, r.Method)
+		assert.Equal(t, "/api/docs/doc1/access", r.URL.Path)
+		body, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{"delta":{"maxInheritedRole":null,"users":{"a@example.com":"editors","b@example.com":null}}}`, string(body))
+	}))
+	defer server.Close()
+
+	client, _ := NewGristClient(context.Background(), server.URL, "valid-key")
+	doc := &Doc{ID: "doc1"}
+	none := AccessRoleNone
+	editor := AccessRoleEditor
+	err := doc.ModifyAccess(client, AccessDelta{
+		MaxInheritedRole: &none,
+		Users: map[string]*AccessRole{
+			"a@example.com": &editor,
+			"b@example.com": nil,
+		},
+	})
+	assert.NoError(t, err)
+}
+
+func TestGetAccess(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		get  func(c *Client) (*Access, error)
+	}{
+		{name: "Org", path: "/api/orgs/2/access", get: (&Org{ID: 2}).GetAccess},
+		{name: "Workspace", path: "/api/workspaces/3/access", get: (&Workspace{ID: 3}).GetAccess},
+		{name: "Doc", path: "/api/docs/doc1/access", get: (&Doc{ID: "doc1"}).GetAccess},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodGet, r.Method)
+				assert.Equal(t, tt.path, r.URL.Path)
+				w.Write([]byte(`{"maxInheritedRole":null,"users":[
+					{"id":1,"name":"Ann","email":"ann@example.com","access":"owners","isMember":true},
+					{"id":2,"name":"Bob","email":"bob@example.com","access":null,"parentAccess":"viewers","isMember":false}
+				]}`))
+			}))
+			defer server.Close()
+
+			client, _ := NewGristClient(context.Background(), server.URL, "valid-key")
+			access, err := tt.get(client)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			assert.Equal(t, AccessRoleNone, access.MaxInheritedRole)
+			assert.Equal(t, []User{
+				{ID: 1, Name: "Ann", Email: "ann@example.com", Access: AccessRoleOwner, Member: true},
+				{ID: 2, Name: "Bob", Email: "bob@example.com", Access: AccessRoleNone, ParentAccess: AccessRoleViewer},
+			}, access.Users)
+		})
+	}
+}
+
+func TestOrg_ModifyAccess(t *testing.T) {
+	t.Run("Sends the users without maxInheritedRole", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPatch, r.Method)
+			assert.Equal(t, "/api/orgs/2/access", r.URL.Path)
+			body, _ := io.ReadAll(r.Body)
+			assert.JSONEq(t, `{"delta":{"users":{"a@example.com":"members","b@example.com":null}}}`, string(body))
+		}))
+		defer server.Close()
+
+		client, _ := NewGristClient(context.Background(), server.URL, "valid-key")
+		org := &Org{ID: 2}
+		member := AccessRoleMember
+		err := org.ModifyAccess(client, map[string]*AccessRole{
+			"a@example.com": &member,
+			"b@example.com": nil,
+		})
+		assert.NoError(t, err)
+	})
+	t.Run("Without users returns error", func(t *testing.T) {
+		client, _ := NewGristClient(context.Background(), "https://getgrist.com", "valid-key")
+		org := &Org{ID: 2}
+		assert.EqualError(t, org.ModifyAccess(client, nil), "access delta cannot be empty")
+	})
+}
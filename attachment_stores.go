@@ -0,0 +1,254 @@
+package grist
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// ArchiveFormat is the format of an attachments archive
+type ArchiveFormat string
+
+const (
+	ArchiveFormatTar ArchiveFormat = "tar"
+	ArchiveFormatZip ArchiveFormat = "zip"
+)
+
+// AttachmentStoreType is where the attachments of a document are stored
+type AttachmentStoreType string
+
+const (
+	// AttachmentStoreInternal stores attachments in the .grist file
+	AttachmentStoreInternal AttachmentStoreType = "internal"
+	// AttachmentStoreExternal stores attachments in the external store configured on the instance
+	AttachmentStoreExternal AttachmentStoreType = "external"
+)
+
+// AttachmentStore is the attachment store setting of a document
+type AttachmentStore struct {
+	Type AttachmentStoreType `json:"type"`
+}
+
+// AttachmentStoreConfig is an attachment store available on the instance
+type AttachmentStoreConfig struct {
+	Label string `json:"label"`
+}
+
+type AttachmentStores struct {
+	Stores []AttachmentStoreConfig `json:"stores"`
+}
+
+// AttachmentArchiveUploadResult summarizes the restoration of an attachments archive
+type AttachmentArchiveUploadResult struct {
+	Added   int `json:"added"`
+	Errored int `json:"errored"`
+	Unused  int `json:"unused"`
+}
+
+// AttachmentTransferStatus reports the progress of a transfer between attachment stores
+type AttachmentTransferStatus struct {
+	Status struct {
+		PendingTransferCount int  `json:"pendingTransferCount"`
+		IsRunning            bool `json:"isRunning"`
+		Successes            int  `json:"successes"`
+		Failures             int  `json:"failures"`
+	} `json:"status"`
+	// LocationSummary is "none", "internal", "external" or "mixed"
+	LocationSummary string `json:"locationSummary"`
+}
+
+// RemoveUnusedAttachments deletes attachments no longer referenced by any cell.
+// With expiredOnly, only attachments unused for long enough are removed.
+// source: https://support.getgrist.com/api/#tag/attachments/operation/removeUnusedAttachments
+func (d *Doc) RemoveUnusedAttachments(c *Client, expiredOnly bool) error {
+	endpoint := buildURL(c.ApiEndpoint(), pathAttachments(d.ID)+"/removeUnused")
+	values := url.Values{}
+	if expiredOnly {
+		values.Set("expiredonly", "1")
+	}
+
+	resp, err := c.PostRequest(
+		endpoint,
+		withAuth(c.ApiKey),
+		withQuery(values),
+	)
+	if err != nil {
+		return err
+	}
+	return handleStatus(resp, http.StatusOK)
+}
+
+// DownloadAttachmentsArchive streams every attachment of the document as a tar or zip archive.
+// The caller must close the returned reader.
+// source: https://support.getgrist.com/api/#tag/attachments/operation/downloadAttachments
+func (d *Doc) DownloadAttachmentsArchive(c *Client, format ArchiveFormat) (io.ReadCloser, error) {
+	endpoint := buildURL(c.ApiEndpoint(), pathAttachments(d.ID)+"/archive")
+	values := url.Values{}
+	if format != "" {
+		values.Set("format", string(format))
+	}
+
+	resp, err := c.GetRequest(
+		endpoint,
+		withAuth(c.ApiKey),
+		withQuery(values),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return handleStreamResponse(resp, http.StatusOK)
+}
+
+// UploadAttachmentsArchive restores missing attachments from a tar archive
+// produced by DownloadAttachmentsArchive.
+// source: https://support.getgrist.com/api/#tag/attachments/operation/uploadAttachmentsArchive
+func (d *Doc) UploadAttachmentsArchive(c *Client, archive FileUpload) (*AttachmentArchiveUploadResult, error) {
+	if archive.FileName == "" || archive.Content == nil {
+		return nil, fmt.Errorf("UploadAttachmentsArchive: file name and content are required")
+	}
+
+	endpoint := buildURL(c.ApiEndpoint(), pathAttachments(d.ID)+"/archive")
+	resp, err := c.PostRequest(
+		endpoint,
+		withAuth(c.ApiKey),
+		withMultipartForm(nil, "upload", []FileUpload{archive}),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var result AttachmentArchiveUploadResult
+	if err := handleJSONResponse(resp, &result, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetAttachmentStore returns the attachment store used by the document.
+// source: https://support.getgrist.com/api/#tag/attachments/operation/getAttachmentStore
+func (d *Doc) GetAttachmentStore(c *Client) (*AttachmentStore, error) {
+	endpoint := buildURL(c.ApiEndpoint(), pathAttachments(d.ID)+"/store")
+	resp, err := c.GetRequest(
+		endpoint,
+		withAuth(c.ApiKey),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var store AttachmentStore
+	if err := handleJSONResponse(resp, &store, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &store, nil
+}
+
+// SetAttachmentStore changes the store used for new attachments of the document.
+// Existing attachments are moved with TransferAllAttachments.
+// source: https://support.getgrist.com/api/#tag/attachments/operation/setAttachmentStore
+func (d *Doc) SetAttachmentStore(c *Client, storeType AttachmentStoreType) error {
+	if storeType != AttachmentStoreInternal && storeType != AttachmentStoreExternal {
+		return fmt.Errorf("SetAttachmentStore: invalid store type %q", storeType)
+	}
+
+	endpoint := buildURL(c.ApiEndpoint(), pathAttachments(d.ID)+"/store")
+	jsonBody, err := withJSONBody(AttachmentStore{Type: storeType})
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.PostRequest(
+		endpoint,
+		withAuth(c.ApiKey),
+		jsonBody,
+	)
+	if err != nil {
+		return err
+	}
+	return handleStatus(resp, http.StatusOK)
+}
+
+// ListAttachmentStores lists the attachment stores available on the instance.
+// source: https://support.getgrist.com/api/#tag/attachments/operation/listAttachmentStores
+func (d *Doc) ListAttachmentStores(c *Client) (*AttachmentStores, error) {
+	endpoint := buildURL(c.ApiEndpoint(), pathAttachments(d.ID)+"/stores")
+	resp, err := c.GetRequest(
+		endpoint,
+		withAuth(c.ApiKey),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var stores AttachmentStores
+	if err := handleJSONResponse(resp, &stores, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &stores, nil
+}
+
+// TransferAllAttachments starts moving every attachment to the current store of the document.
+// source: https://support.getgrist.com/api/#tag/attachments/operation/transferAllAttachments
+func (d *Doc) TransferAllAttachments(c *Client) (*AttachmentTransferStatus, error) {
+	endpoint := buildURL(c.ApiEndpoint(), pathAttachments(d.ID)+"/transferAll")
+	resp, err := c.PostRequest(
+		endpoint,
+		withAuth(c.ApiKey),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var status AttachmentTransferStatus
+	if err := handleJSONResponse(resp, &status, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// GetAttachmentTransferStatus returns the progress of the current attachments transfer.
+// source: https://support.getgrist.com/api/#tag/attachments/operation/getAttachmentTransferStatus
+func (d *Doc) GetAttachmentTransferStatus(c *Client) (*AttachmentTransferStatus, error) {
+	endpoint := buildURL(c.ApiEndpoint(), pathAttachments(d.ID)+"/transferStatus")
+	resp, err := c.GetRequest(
+		endpoint,
+		withAuth(c.ApiKey),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var status AttachmentTransferStatus
+	if err := handleJSONResponse(resp, &status, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// WaitAttachmentTransfer polls the transfer status every interval until the
+// transfer stops or the client context is done, and returns the last status.
+func (d *Doc) WaitAttachmentTransfer(c *Client, interval time.Duration) (*AttachmentTransferStatus, error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("WaitAttachmentTransfer: invalid interval: %s", interval)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		status, err := d.GetAttachmentTransferStatus(c)
+		if err != nil {
+			return nil, err
+		}
+		if !status.Status.IsRunning {
+			return status, nil
+		}
+
+		select {
+		case <-c.Context.Done():
+			return status, c.Context.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package grist
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDoc_RemoveUnusedAttachments(t *testing.T) {
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/api/docs/doc1/attachments/removeUnused", r.URL.Path)
+		queries = append(queries, r.URL.RawQuery)
+	}))
+	defer server.Close()
+
+	client, _ := NewGristClient(context.Background(), server.URL, "valid-key")
+	doc := &Doc{ID: "doc1"}
+	assert.NoError(t, doc.RemoveUnusedAttachments(client, true))
+	assert.NoError(t, doc.RemoveUnusedAttachments(client, false))
+	assert.Equal(t, []string{"expiredonly=1", ""}, queries)
+}
+
+func TestDoc_DownloadAttachmentsArchive(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/api/docs/doc1/attachments/archive", r.URL.Path)
+		assert.Equal(t, "zip", r.URL.Query().Get("format"))
+		w.Write([]byte("PK"))
+	}))
+	defer server.Close()
+
+	client, _ := NewGristClient(context.Background(), server.URL, "valid-key")
+	doc := &Doc{ID: "doc1"}
+	rc, err := doc.DownloadAttachmentsArchive(client, ArchiveFormatZip)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer rc.Close()
+	content, _ := io.ReadAll(rc)
+	assert.Equal(t, "PK", string(content))
+}
+
+func TestDoc_UploadAttachmentsArchive(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/api/docs/doc1/attachments/archive", r.URL.Path)
+		f, header, err := r.FormFile("upload")
+		if err != nil {
+			t.Fatalf("Expected upload file, got %v", err)
+		}
+		content, _ := io.ReadAll(f)
+		assert.Equal(t, "attachments.tar", header.Filename)
+		assert.Equal(t, "tar", string(content))
+		w.Write([]byte(`{"added": 3, "errored": 1, "unused": 2}`))
+	}))
+	defer server.Close()
+
+	client, _ := NewGristClient(context.Background(), server.URL, "valid-key")
+	doc := &Doc{ID: "doc1"}
+	result, err := doc.UploadAttachmentsArchive(client, FileUpload{FileName: "attachments.tar", Content: strings.NewReader("tar")})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	assert.Equal(t, &AttachmentArchiveUploadResult{Added: 3, Errored: 1, Unused: 2}, result)
+
+	_, err = doc.UploadAttachmentsArchive(client, FileUpload{})
+	assert.Error(t, err)
+}
+
+func TestDoc_AttachmentStore(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /api/docs/doc1/attachments/store":
+			w.Write([]byte(`{"type": "internal"}`))
+		case "POST /api/docs/doc1/attachments/store":
+			body, _ := io.ReadAll(r.Body)
+			assert.JSONEq(t, `{"type": "external"}`, string(body))
+		case "GET /api/docs/doc1/attachments/stores":
+			w.Write([]byte(`{"stores": [{"label": "s3"}]}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client, _ := NewGristClient(context.Background(), server.URL, "valid-key")
+	doc := &Doc{ID: "doc1"}
+
+	store, err := doc.GetAttachmentStore(client)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	assert.Equal(t, AttachmentStoreInternal, store.Type)
+
+	assert.NoError(t, doc.SetAttachmentStore(client, AttachmentStoreExternal))
+	assert.Error(t, doc.SetAttachmentStore(client, "s3"))
+
+	stores, err := doc.ListAttachmentStores(client)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	assert.Equal(t, []AttachmentStoreConfig{{Label: "s3"}}, stores.Stores)
+}
+
+func TestDoc_TransferAllAttachments(t *testing.T) {
+	var polls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "POST /api/docs/doc1/attachments/transferAll":
+			w.Write([]byte(`{"status": {"pendingTransferCount": 2, "isRunning": true}, "locationSummary": "mixed"}`))
+		case "GET /api/docs/doc1/attachments/transferStatus":
+			if polls.Add(1) < 3 {
+				w.Write([]byte(`{"status": {"pendingTransferCount": 1, "isRunning": true, "successes": 1}, "locationSummary": "mixed"}`))
+				return
+			}
+			w.Write([]byte(`{"status": {"pendingTransferCount": 0, "isRunning": false, "successes": 2}, "locationSummary": "external"}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client, _ := NewGristClient(context.Background(), server.URL, "valid-key")
+	doc := &Doc{ID: "doc1"}
+
+	status, err := doc.TransferAllAttachments(client)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	assert.True(t, status.Status.IsRunning)
+	assert.Equal(t, 2, status.Status.PendingTransferCount)
+
+	status, err = doc.WaitAttachmentTransfer(client, time.Millisecond)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	assert.False(t, status.Status.IsRunning)
+	assert.Equal(t, "external", status.LocationSummary)
+	assert.Equal(t, int32(3), polls.Load())
+}
+
+func TestDoc_WaitAttachmentTransfer(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status": {"isRunning": true}, "locationSummary": "mixed"}`))
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	client, _ := NewGristClient(ctx, server.URL, "valid-key")
+	doc := &Doc{ID: "doc1"}
+
+	_, err := doc.WaitAttachmentTransfer(client, 5*time.Millisecond)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	_, err = doc.WaitAttachmentTransfer(client, 0)
+	assert.Error(t, err)
+}
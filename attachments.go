@@ -0,0 +1,140 @@
+package grist
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// AttachmentMetadata describes an attachment.
+// source: https://support.getgrist.com/api/#tag/attachments/operation/getAttachmentMetadata
+type AttachmentMetadata struct {
+	FileName     string    `json:"fileName"`
+	FileSize     int64     `json:"fileSize"`
+	TimeUploaded time.Time `json:"timeUploaded"`
+}
+
+type Attachments struct {
+	Records []Attachment `json:"records"`
+}
+
+type Attachment struct {
+	ID     int                `json:"id"`
+	Fields AttachmentMetadata `json:"fields"`
+}
+
+// AttachmentUpload is a file uploaded by UploadAttachments.
+//
+// Deprecated: use FileUpload, shared with the other upload endpoints.
+type AttachmentUpload = FileUpload
+
+func pathAttachments(docID string) string {
+	return pathDescribeDocs(docID) + "/attachments"
+}
+
+func pathAttachment(docID string, attachmentID int) string {
+	return pathAttachments(docID) + "/" + strconv.Itoa(attachmentID)
+}
+
+// NewAttachmentsCell returns the value of an Attachments cell referencing attachmentIDs
+func NewAttachmentsCell(attachmentIDs ...int) *CellValue {
+	items := make([]*CellValue, 0, len(attachmentIDs))
+	for _, id := range attachmentIDs {
+		items = append(items, NewNumberCell(float64(id)))
+	}
+	return NewListCell(items...)
+}
+
+// UploadAttachments uploads files to the document and returns the new attachment IDs.
+// source: https://support.getgrist.com/api/#tag/attachments/operation/uploadAttachments
+func (d *Doc) UploadAttachments(c *Client, files ...FileUpload) ([]int, error) {
+	if len(files) == 0 {
+		return nil, fmt.Errorf("UploadAttachments: files cannot be empty")
+	}
+	for _, f := range files {
+		if f.FileName == "" || f.Content == nil {
+			return nil, fmt.Errorf("UploadAttachments: file name and content are required")
+		}
+	}
+
+	endpoint := buildURL(c.ApiEndpoint(), pathAttachments(d.ID))
+	resp, err := c.PostRequest(
+		endpoint,
+		withAuth(c.ApiKey),
+		withMultipartForm(nil, "upload", files),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []int
+	if err := handleJSONResponse(resp, &ids, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// ListAttachments lists the attachments of the document, opts may be nil.
+// source: https://support.getgrist.com/api/#tag/attachments/operation/listAttachments
+func (d *Doc) ListAttachments(c *Client, opts *QueryOptions) (*Attachments, error) {
+	endpoint := buildURL(c.ApiEndpoint(), pathAttachments(d.ID))
+	queryOpts, err := opts.requestOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.GetRequest(
+		endpoint,
+		append([]requestOption{withAuth(c.ApiKey)}, queryOpts...)...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var attachments Attachments
+	if err := handleJSONResponse(resp, &attachments, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &attachments, nil
+}
+
+// DescribeAttachment fetches the metadata of an attachment.
+// source: https://support.getgrist.com/api/#tag/attachments/operation/getAttachmentMetadata
+func (d *Doc) DescribeAttachment(c *Client, attachmentID int) (*AttachmentMetadata, error) {
+	endpoint := buildURL(c.ApiEndpoint(), pathAttachment(d.ID, attachmentID))
+	resp, err := c.GetRequest(
+		endpoint,
+		withAuth(c.ApiKey),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var metadata AttachmentMetadata
+	if err := handleJSONResponse(resp, &metadata, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &metadata, nil
+}
+
+// DownloadAttachment streams the content of an attachment with its content type.
+// The caller must close the returned reader.
+// source: https://support.getgrist.com/api/#tag/attachments/operation/downloadAttachment
+func (d *Doc) DownloadAttachment(c *Client, attachmentID int) (io.ReadCloser, string, error) {
+	endpoint := buildURL(c.ApiEndpoint(), pathAttachment(d.ID, attachmentID)+"/download")
+	resp, err := c.GetRequest(
+		endpoint,
+		withAuth(c.ApiKey),
+	)
+	if err != nil {
+		return nil, "", err
+	}
+
+	body, err := handleStreamResponse(resp, http.StatusOK)
+	if err != nil {
+		return nil, "", err
+	}
+	return body, resp.Header.Get("Content-Type"), nil
+}
@@ -0,0 +1,150 @@
+package grist
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDoc_UploadAttachments(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/docs/doc1/attachments", r.URL.Path)
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Fatalf("Expected multipart body, got %v", err)
+		}
+		files := r.MultipartForm.File["upload"]
+		assert.Len(t, files, 2)
+		assert.Equal(t, "a.txt", files[0].Filename)
+
+		f, _ := files[1].Open()
+		content, _ := io.ReadAll(f)
+		assert.Equal(t, "bbb", string(content))
+		w.Write([]byte(`[4,5]`))
+	}))
+	defer server.Close()
+
+	client, _ := NewGristClient(context.Background(), server.URL, "valid-key")
+	doc := &Doc{ID: "doc1"}
+	ids, err := doc.UploadAttachments(client,
+		FileUpload{FileName: "a.txt", Content: strings.NewReader("aaa")},
+		FileUpload{FileName: "b.txt", Content: strings.NewReader("bbb")},
+	)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	assert.Equal(t, []int{4, 5}, ids)
+}
+
+func TestDoc_ListAttachments(t *testing.T) {
+	t.Run("With options sends them in the query string", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodGet, r.Method)
+			assert.Equal(t, "/api/docs/doc1/attachments", r.URL.Path)
+			q := r.URL.Query()
+			assert.Equal(t, `{"fileName":["pets.png"]}`, q.Get("filter"))
+			assert.Equal(t, "-timeUploaded", q.Get("sort"))
+			assert.Equal(t, "5", q.Get("limit"))
+			w.Write([]byte(`{"records":[
+				{"id":4,"fields":{"fileName":"pets.png","fileSize":2048,"timeUploaded":"2024-05-06T07:08:09.000Z"}}
+			]}`))
+		}))
+		defer server.Close()
+
+		client, _ := NewGristClient(context.Background(), server.URL, "valid-key")
+		doc := &Doc{ID: "doc1"}
+		attachments, err := doc.ListAttachments(client, &QueryOptions{
+			Filter: map[string][]any{"fileName": {"pets.png"}},
+			Sort:   []SortKey{{Column: "timeUploaded", Descending: true}},
+			Limit:  5,
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		assert.Equal(t, []Attachment{{
+			ID: 4,
+			Fields: AttachmentMetadata{
+				FileName:     "pets.png",
+				FileSize:     2048,
+				TimeUploaded: time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC),
+			},
+		}}, attachments.Records)
+	})
+	t.Run("Without options sends no query string", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Empty(t, r.URL.RawQuery)
+			w.Write([]byte(`{"records":[]}`))
+		}))
+		defer server.Close()
+
+		client, _ := NewGristClient(context.Background(), server.URL, "valid-key")
+		doc := &Doc{ID: "doc1"}
+		attachments, err := doc.ListAttachments(client, nil)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		assert.Empty(t, attachments.Records)
+	})
+}
+
+func TestDoc_DescribeAttachment(t *testing.T) {
+	t.Run("Decodes the metadata", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/api/docs/doc1/attachments/4", r.URL.Path)
+			w.Write([]byte(`{"fileName":"pets.png","fileSize":2048,"timeUploaded":"2024-05-06T07:08:09.000Z"}`))
+		}))
+		defer server.Close()
+
+		client, _ := NewGristClient(context.Background(), server.URL, "valid-key")
+		doc := &Doc{ID: "doc1"}
+		metadata, err := doc.DescribeAttachment(client, 4)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		assert.Equal(t, "pets.png", metadata.FileName)
+		assert.Equal(t, int64(2048), metadata.FileSize)
+	})
+	t.Run("With unknown attachment returns ErrNotFound", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":"Attachment not found: 4"}`))
+		}))
+		defer server.Close()
+
+		client, _ := NewGristClient(context.Background(), server.URL, "valid-key")
+		doc := &Doc{ID: "doc1"}
+		_, err := doc.DescribeAttachment(client, 4)
+		assert.ErrorIs(t, err, ErrNotFound)
+
+		var apiErr *APIError
+		if assert.ErrorAs(t, err, &apiErr) {
+			assert.Equal(t, "Attachment not found: 4", apiErr.Message)
+		}
+	})
+}
+
+func TestDoc_DownloadAttachment(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/docs/doc1/attachments/4/download", r.URL.Path)
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write([]byte("aaa"))
+	}))
+	defer server.Close()
+
+	client, _ := NewGristClient(context.Background(), server.URL, "valid-key")
+	doc := &Doc{ID: "doc1"}
+	body, contentType, err := doc.DownloadAttachment(client, 4)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer body.Close()
+
+	content, _ := io.ReadAll(body)
+	assert.Equal(t, "aaa", string(content))
+	assert.Equal(t, "text/plain", contentType)
+}
@@ -16,6 +16,9 @@ type Client struct {
 	ApiKey     string
 	HTTPClient *http.Client
 	Context    context.Context
+	// Retry sets how failed requests are retried, nil makes a single attempt.
+	// See DefaultRetryPolicy.
+	Retry *RetryPolicy
 }
 
 // ApiEndpoint returns the API base endpoint for the Grist instance
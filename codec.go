@@ -0,0 +1,416 @@
+package grist
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// The codec maps Go structs to Grist records using `grist:"ColumnId"` tags.
+//
+//	type Pet struct {
+//		ID       int        `grist:"id"`
+//		Name     string     `grist:"Name"`
+//		Born     time.Time  `grist:"Born,date"`
+//		Owner    int        `grist:"Owner"`
+//		Tags     []string   `grist:"Tags"`
+//		Adopted  *time.Time `grist:"Adopted"`
+//		Internal string     `grist:"-"`
+//	}
+//
+// The "id" column maps to the record row ID. Untagged exported fields use the
+// field name as column ID. The "date" option truncates a time.Time to midnight
+// UTC as expected by Date columns, DateTime columns take the time as is.
+// Slices map to Grist lists (["L", ...]), ints to Ref columns and nil pointers
+// to empty cells.
+
+const (
+	tagName     = "grist"
+	rowIDColumn = "id"
+)
+
+var (
+	timeType      = reflect.TypeOf(time.Time{})
+	cellValueType = reflect.TypeOf(CellValue{})
+)
+
+type codecField struct {
+	column string
+	index  []int
+	date   bool
+}
+
+// codecFields returns the mapped fields of a struct type
+func codecFields(t reflect.Type) []codecField {
+	var fields []codecField
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag := sf.Tag.Get(tagName)
+		if tag == "-" {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(tag, ",")
+		if sf.Anonymous && name == "" && sf.Type.Kind() == reflect.Struct && sf.Type != timeType {
+			for _, f := range codecFields(sf.Type) {
+				f.index = append([]int{i}, f.index...)
+				fields = append(fields, f)
+			}
+			continue
+		}
+		if !sf.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		fields = append(fields, codecField{
+			column: name,
+			index:  []int{i},
+			date:   opts == "date",
+		})
+	}
+	return fields
+}
+
+func structValue(v any) (reflect.Value, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return reflect.Value{}, fmt.Errorf("grist codec: nil %s", rv.Type())
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return reflect.Value{}, fmt.Errorf("grist codec: expected struct, got %s", rv.Type())
+	}
+	return rv, nil
+}
+
+// MarshalRecord converts a tagged struct into a Record
+func MarshalRecord(v any) (Record, error) {
+	rv, err := structValue(v)
+	if err != nil {
+		return Record{}, err
+	}
+
+	record := Record{Fields: map[string]*CellValue{}}
+	for _, f := range codecFields(rv.Type()) {
+		fv := rv.FieldByIndex(f.index)
+		if f.column == rowIDColumn {
+			if !fv.CanInt() {
+				return Record{}, fmt.Errorf("grist codec: row id must be an int, got %s", fv.Type())
+			}
+			record.ID = int(fv.Int())
+			continue
+		}
+
+		cell, err := encodeCell(fv, f.date)
+		if err != nil {
+			return Record{}, fmt.Errorf("grist codec: column %s: %w", f.column, err)
+		}
+		record.Fields[f.column] = cell
+	}
+	return record, nil
+}
+
+// UnmarshalRecord fills the tagged struct pointed to by v from a Record.
+// Columns missing from the record leave the struct field untouched.
+func UnmarshalRecord(r Record, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return fmt.Errorf("grist codec: expected non-nil pointer, got %T", v)
+	}
+	rv = rv.Elem()
+	if rv.Kind() != reflect.Struct {
+		return fmt.Errorf("grist codec: expected pointer to struct, got %T", v)
+	}
+
+	for _, f := range codecFields(rv.Type()) {
+		fv := rv.FieldByIndex(f.index)
+		if f.column == rowIDColumn {
+			if !fv.CanInt() {
+				return fmt.Errorf("grist codec: row id must be an int, got %s", fv.Type())
+			}
+			fv.SetInt(int64(r.ID))
+			continue
+		}
+
+		cell, ok := r.Fields[f.column]
+		if !ok {
+			continue
+		}
+		if err := decodeCell(cell, fv); err != nil {
+			return fmt.Errorf("grist codec: column %s: %w", f.column, err)
+		}
+	}
+	return nil
+}
+
+// encodeCell converts a Go value into a CellValue
+func encodeCell(v reflect.Value, date bool) (*CellValue, error) {
+	if v.Type() == cellValueType {
+		c := v.Interface().(CellValue)
+		return &c, nil
+	}
+	if v.Type() == timeType {
+		t := v.Interface().(time.Time)
+		if t.IsZero() {
+			return NewNullCell(), nil
+		}
+		return NewNumberCell(timeToGrist(t, date)), nil
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return NewNullCell(), nil
+		}
+		return encodeCell(v.Elem(), date)
+	case reflect.String:
+		return NewStringCell(v.String()), nil
+	case reflect.Bool:
+		return NewBoolCell(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return NewNumberCell(float64(v.Int())), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return NewNumberCell(float64(v.Uint())), nil
+	case reflect.Float32, reflect.Float64:
+		return NewNumberCell(v.Float()), nil
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return NewNullCell(), nil
+		}
+		data := make([]any, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			elem, err := encodeCell(v.Index(i), date)
+			if err != nil {
+				return nil, err
+			}
+			data = append(data, elem.value())
+		}
+		return newObjectCell(ObjCodeList, data...), nil
+	default:
+		return nil, fmt.Errorf("unsupported type %s", v.Type())
+	}
+}
+
+// decodeCell assigns a CellValue to a settable Go value
+func decodeCell(c *CellValue, v reflect.Value) error {
+	if v.Type() == cellValueType {
+		if c != nil {
+			v.Set(reflect.ValueOf(*c))
+		}
+		return nil
+	}
+	if c == nil || c.Null {
+		v.Set(reflect.Zero(v.Type()))
+		return nil
+	}
+
+	if v.Kind() == reflect.Pointer {
+		elem := reflect.New(v.Type().Elem())
+		if err := decodeCell(c, elem.Elem()); err != nil {
+			return err
+		}
+		v.Set(elem)
+		return nil
+	}
+
+	if v.Type() == timeType {
+		t, ok := cellTime(c)
+		if !ok {
+			return fmt.Errorf("cannot decode %s into time.Time", c.describe())
+		}
+		v.Set(reflect.ValueOf(t))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Interface:
+		if v.NumMethod() != 0 {
+			return fmt.Errorf("unsupported type %s", v.Type())
+		}
+		if val := c.value(); val != nil {
+			v.Set(reflect.ValueOf(val))
+		}
+		return nil
+	case reflect.String:
+		if c.String == nil {
+			return fmt.Errorf("cannot decode %s into string", c.describe())
+		}
+		v.SetString(*c.String)
+	case reflect.Bool:
+		if c.Boolean == nil {
+			return fmt.Errorf("cannot decode %s into bool", c.describe())
+		}
+		v.SetBool(*c.Boolean)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if c.Number == nil || *c.Number != math.Trunc(*c.Number) {
+			return fmt.Errorf("cannot decode %s into %s", c.describe(), v.Type())
+		}
+		if v.OverflowInt(int64(*c.Number)) {
+			return fmt.Errorf("%v overflows %s", *c.Number, v.Type())
+		}
+		v.SetInt(int64(*c.Number))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if c.Number == nil || *c.Number < 0 || *c.Number != math.Trunc(*c.Number) {
+			return fmt.Errorf("cannot decode %s into %s", c.describe(), v.Type())
+		}
+		if v.OverflowUint(uint64(*c.Number)) {
+			return fmt.Errorf("%v overflows %s", *c.Number, v.Type())
+		}
+		v.SetUint(uint64(*c.Number))
+	case reflect.Float32, reflect.Float64:
+		if c.Number == nil {
+			return fmt.Errorf("cannot decode %s into %s", c.describe(), v.Type())
+		}
+		v.SetFloat(*c.Number)
+	case reflect.Slice:
+		items, ok := c.AsList()
+		if !ok {
+			return fmt.Errorf("cannot decode %s into %s", c.describe(), v.Type())
+		}
+		s := reflect.MakeSlice(v.Type(), len(items), len(items))
+		for i, item := range items {
+			if err := decodeCell(item, s.Index(i)); err != nil {
+				return fmt.Errorf("list item %d: %w", i, err)
+			}
+		}
+		v.Set(s)
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+	return nil
+}
+
+// timeToGrist converts a time into Grist seconds since epoch, truncated to
+// midnight UTC for Date columns
+func timeToGrist(t time.Time, date bool) float64 {
+	if date {
+		t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+		return float64(t.Unix())
+	}
+	return float64(t.UnixNano()) / float64(time.Second)
+}
+
+// timeFromGrist converts Grist seconds since epoch into a UTC time
+func timeFromGrist(ts float64) time.Time {
+	sec, frac := math.Modf(ts)
+	return time.Unix(int64(sec), int64(math.Round(frac*float64(time.Second)))).UTC()
+}
+
+// cellTime returns the time held by a numeric, date or datetime cell
+func cellTime(c *CellValue) (time.Time, bool) {
+	if c.Number != nil {
+		return timeFromGrist(*c.Number), true
+	}
+	if t, ok := c.AsDate(); ok {
+		return t, true
+	}
+	return c.AsDateTime(nil)
+}
+
+// value returns the plain Go value of the cell as produced by encoding/json,
+// a nil cell is null
+func (c *CellValue) value() any {
+	switch {
+	case c == nil:
+		return nil
+	case c.Number != nil:
+		return *c.Number
+	case c.String != nil:
+		return *c.String
+	case c.Boolean != nil:
+		return *c.Boolean
+	case c.Object != nil:
+		return append([]any{c.Object.Code}, c.Object.Data...)
+	default:
+		return nil
+	}
+}
+
+// describe returns a short description of the cell content for error messages
+func (c *CellValue) describe() string {
+	switch {
+	case c.Number != nil:
+		return "number"
+	case c.String != nil:
+		return "string"
+	case c.Boolean != nil:
+		return "bool"
+	case c.Null:
+		return "null"
+	case c.Object != nil:
+		return fmt.Sprintf("object %q", c.Object.Code)
+	default:
+		return "empty cell"
+	}
+}
+
+// cellFromValue builds a CellValue from a value decoded by encoding/json
+func cellFromValue(v any) *CellValue {
+	switch x := v.(type) {
+	case nil:
+		return NewNullCell()
+	case float64:
+		return NewNumberCell(x)
+	case string:
+		return NewStringCell(x)
+	case bool:
+		return NewBoolCell(x)
+	case []any:
+		if len(x) > 0 {
+			if code, ok := x[0].(string); ok {
+				return &CellValue{Object: &ObjectGrist{Code: code, Data: x[1:]}}
+			}
+		}
+	}
+	return &CellValue{}
+}
+
+// ListRecordsAs lists records for a table and decodes them into T.
+// source: https://support.getgrist.com/api/#tag/records/operation/listRecords
+func ListRecordsAs[T any](c *Client, d *Doc, tableID string, opts *QueryOptions) ([]T, error) {
+	records, err := d.ListRecords(c, tableID, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]T, len(records.Records))
+	for i, r := range records.Records {
+		if err := UnmarshalRecord(r, &out[i]); err != nil {
+			return nil, fmt.Errorf("record %d: %w", r.ID, err)
+		}
+	}
+	return out, nil
+}
+
+// CreateRecordsFrom encodes values as records, creates them in a table and
+// returns the new row IDs.
+// source: https://support.getgrist.com/api/#tag/records/operation/addRecords
+func CreateRecordsFrom[T any](c *Client, d *Doc, tableID string, values []T) ([]int, error) {
+	obj := Records{Records: make([]Record, 0, len(values))}
+	for i := range values {
+		r, err := MarshalRecord(&values[i])
+		if err != nil {
+			return nil, err
+		}
+		// Grist rejects ids on creation
+		r.ID = 0
+		obj.Records = append(obj.Records, r)
+	}
+
+	created, err := d.CreateRecords(c, tableID, obj)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]int, 0, len(created.Records))
+	for _, r := range created.Records {
+		ids = append(ids, r.ID)
+	}
+	return ids, nil
+}
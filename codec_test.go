@@ -0,0 +1,108 @@
+package grist
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type codecPet struct {
+	ID       int        `grist:"id"`
+	Name     string     `grist:"Name"`
+	Born     time.Time  `grist:"Born,date"`
+	Seen     time.Time  `grist:"Seen"`
+	Owner    int        `grist:"Owner"`
+	Tags     []string   `grist:"Tags"`
+	Friends  []int      `grist:"Friends"`
+	Weight   *float64   `grist:"Weight"`
+	Adopted  *time.Time `grist:"Adopted"`
+	Internal string     `grist:"-"`
+}
+
+func TestMarshalRecord(t *testing.T) {
+	born := time.Date(2020, 3, 4, 15, 30, 0, 0, time.UTC)
+	pet := codecPet{
+		ID:       7,
+		Name:     "Rex",
+		Born:     born,
+		Seen:     born,
+		Owner:    2,
+		Tags:     []string{"dog", "good"},
+		Friends:  []int{1, 3},
+		Internal: "ignored",
+	}
+
+	record, err := MarshalRecord(pet)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	assert.Equal(t, 7, record.ID)
+	assert.NotContains(t, record.Fields, "Internal")
+
+	b, err := json.Marshal(record)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	assert.JSONEq(t, `{
+		"id": 7,
+		"fields": {
+			"Name": "Rex",
+			"Born": 1583280000,
+			"Seen": 1583335800,
+			"Owner": 2,
+			"Tags": ["L", "dog", "good"],
+			"Friends": ["L", 1, 3],
+			"Weight": null,
+			"Adopted": null
+		}
+	}`, string(b))
+}
+
+func TestUnmarshalRecord(t *testing.T) {
+	t.Run("With valid record fills struct", func(t *testing.T) {
+		var record Record
+		err := json.Unmarshal([]byte(`{
+			"id": 7,
+			"fields": {
+				"Name": "Rex",
+				"Born": 1583280000,
+				"Owner": 2,
+				"Tags": ["L", "dog", "good"],
+				"Friends": ["L", 1, 3],
+				"Weight": 12.5,
+				"Adopted": null
+			}
+		}`), &record)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		var pet codecPet
+		if err := UnmarshalRecord(record, &pet); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		assert.Equal(t, 7, pet.ID)
+		assert.Equal(t, "Rex", pet.Name)
+		assert.Equal(t, time.Date(2020, 3, 4, 0, 0, 0, 0, time.UTC), pet.Born)
+		assert.Equal(t, 2, pet.Owner)
+		assert.Equal(t, []string{"dog", "good"}, pet.Tags)
+		assert.Equal(t, []int{1, 3}, pet.Friends)
+		assert.Equal(t, 12.5, *pet.Weight)
+		assert.Nil(t, pet.Adopted)
+	})
+	t.Run("With mismatched type returns error", func(t *testing.T) {
+		record := Record{Fields: map[string]*CellValue{"Name": NewNumberCell(3)}}
+
+		var pet codecPet
+		err := UnmarshalRecord(record, &pet)
+		assert.EqualError(t, err, "grist codec: column Name: cannot decode number into string")
+	})
+	t.Run("With non pointer returns error", func(t *testing.T) {
+		err := UnmarshalRecord(Record{}, codecPet{})
+		assert.Error(t, err)
+	})
+}
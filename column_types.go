@@ -0,0 +1,274 @@
+package grist
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// ColumnKind is the base kind of a Grist column type, without its parameter
+type ColumnKind string
+
+// Column kinds.
+// source: https://support.getgrist.com/col-types/
+const (
+	KindAny         ColumnKind = "Any"
+	KindText        ColumnKind = "Text"
+	KindNumeric     ColumnKind = "Numeric"
+	KindInt         ColumnKind = "Int"
+	KindBool        ColumnKind = "Bool"
+	KindDate        ColumnKind = "Date"
+	KindDateTime    ColumnKind = "DateTime"
+	KindChoice      ColumnKind = "Choice"
+	KindChoiceList  ColumnKind = "ChoiceList"
+	KindRef         ColumnKind = "Ref"
+	KindRefList     ColumnKind = "RefList"
+	KindAttachments ColumnKind = "Attachments"
+)
+
+var tableIDPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// ColumnType is a Grist column type such as "Text", "DateTime:Europe/Paris" or "Ref:People".
+// Types unknown to this package (e.g. "ManualSortPos") are kept as is.
+type ColumnType struct {
+	Kind ColumnKind
+	// Param is the timezone of DateTime columns or the table ID of Ref and RefList columns
+	Param string
+}
+
+// Column types without parameter
+var (
+	TypeAny         = ColumnType{Kind: KindAny}
+	TypeText        = ColumnType{Kind: KindText}
+	TypeNumeric     = ColumnType{Kind: KindNumeric}
+	TypeInt         = ColumnType{Kind: KindInt}
+	TypeBool        = ColumnType{Kind: KindBool}
+	TypeDate        = ColumnType{Kind: KindDate}
+	TypeChoice      = ColumnType{Kind: KindChoice}
+	TypeChoiceList  = ColumnType{Kind: KindChoiceList}
+	TypeAttachments = ColumnType{Kind: KindAttachments}
+)
+
+// TypeDateTime returns a DateTime column type in the given timezone
+func TypeDateTime(tz string) ColumnType {
+	return ColumnType{Kind: KindDateTime, Param: tz}
+}
+
+// TypeRef returns a Ref column type pointing to tableID
+func TypeRef(tableID string) ColumnType {
+	return ColumnType{Kind: KindRef, Param: tableID}
+}
+
+// TypeRefList returns a RefList column type pointing to tableID
+func TypeRefList(tableID string) ColumnType {
+	return ColumnType{Kind: KindRefList, Param: tableID}
+}
+
+// ParseColumnType parses a Grist column type string
+func ParseColumnType(s string) (ColumnType, error) {
+	if s == "" {
+		return ColumnType{}, fmt.Errorf("column type cannot be empty")
+	}
+
+	kind, param, _ := strings.Cut(s, ":")
+	t := ColumnType{Kind: ColumnKind(kind), Param: param}
+	if err := t.Validate(); err != nil {
+		return ColumnType{}, err
+	}
+	return t, nil
+}
+
+// Validate checks the parameter of the column type
+func (t ColumnType) Validate() error {
+	switch t.Kind {
+	case "":
+		return fmt.Errorf("column type cannot be empty")
+	case KindRef, KindRefList:
+		if !tableIDPattern.MatchString(t.Param) {
+			return fmt.Errorf("invalid %s column type: invalid table id %q", t.Kind, t.Param)
+		}
+	case KindDateTime:
+		// A DateTime column without timezone is accepted by Grist
+	case KindAny, KindText, KindNumeric, KindInt, KindBool, KindDate,
+		KindChoice, KindChoiceList, KindAttachments:
+		if t.Param != "" {
+			return fmt.Errorf("invalid %s column type: unexpected parameter %q", t.Kind, t.Param)
+		}
+	}
+	return nil
+}
+
+// IsKnown reports whether the kind is one of the kinds defined by this package
+func (t ColumnType) IsKnown() bool {
+	switch t.Kind {
+	case KindAny, KindText, KindNumeric, KindInt, KindBool, KindDate, KindDateTime,
+		KindChoice, KindChoiceList, KindRef, KindRefList, KindAttachments:
+		return true
+	default:
+		return false
+	}
+}
+
+// String formats the column type as expected by Grist
+func (t ColumnType) String() string {
+	if t.Param == "" {
+		return string(t.Kind)
+	}
+	return string(t.Kind) + ":" + t.Param
+}
+
+func (t ColumnType) MarshalJSON() ([]byte, error) {
+	if err := t.Validate(); err != nil {
+		return nil, err
+	}
+	return json.Marshal(t.String())
+}
+
+// UnmarshalJSON decodes any column type string, unknown kinds included
+func (t *ColumnType) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	kind, param, _ := strings.Cut(s, ":")
+	*t = ColumnType{Kind: ColumnKind(kind), Param: param}
+	return nil
+}
+
+// Alignment is the horizontal alignment of a cell
+type Alignment string
+
+const (
+	AlignLeft   Alignment = "left"
+	AlignCenter Alignment = "center"
+	AlignRight  Alignment = "right"
+)
+
+// NumMode is the display mode of Numeric and Int columns
+type NumMode string
+
+const (
+	NumModeCurrency   NumMode = "currency"
+	NumModeDecimal    NumMode = "decimal"
+	NumModePercent    NumMode = "percent"
+	NumModeScientific NumMode = "scientific"
+)
+
+// ChoiceOptions holds the style of a single choice of a Choice or ChoiceList column
+type ChoiceOptions struct {
+	FillColor         string `json:"fillColor,omitempty"`
+	TextColor         string `json:"textColor,omitempty"`
+	FontBold          bool   `json:"fontBold,omitempty"`
+	FontItalic        bool   `json:"fontItalic,omitempty"`
+	FontUnderline     bool   `json:"fontUnderline,omitempty"`
+	FontStrikethrough bool   `json:"fontStrikethrough,omitempty"`
+}
+
+// WidgetOptions holds the display options of a column. Grist stores them as
+// a JSON string, keys unknown to this package are kept in Extra.
+type WidgetOptions struct {
+	Widget    string    `json:"widget,omitempty"`
+	Alignment Alignment `json:"alignment,omitempty"`
+	WrapText  *bool     `json:"wrap,omitempty"`
+	TextColor string    `json:"textColor,omitempty"`
+	FillColor string    `json:"fillColor,omitempty"`
+
+	// Choice and ChoiceList columns
+	Choices       []string                 `json:"choices,omitempty"`
+	ChoiceOptions map[string]ChoiceOptions `json:"choiceOptions,omitempty"`
+
+	// Numeric and Int columns
+	NumMode     NumMode `json:"numMode,omitempty"`
+	NumSign     string  `json:"numSign,omitempty"`
+	Currency    string  `json:"currency,omitempty"`
+	Decimals    *int    `json:"decimals,omitempty"`
+	MaxDecimals *int    `json:"maxDecimals,omitempty"`
+
+	// Date and DateTime columns, formats use moment.js syntax (e.g. "YYYY-MM-DD")
+	DateFormat         string `json:"dateFormat,omitempty"`
+	IsCustomDateFormat bool   `json:"isCustomDateFormat,omitempty"`
+	TimeFormat         string `json:"timeFormat,omitempty"`
+	IsCustomTimeFormat bool   `json:"isCustomTimeFormat,omitempty"`
+
+	Extra map[string]any `json:"-"`
+}
+
+// widgetOptionsFields is used to encode WidgetOptions without recursing into its methods
+type widgetOptionsFields WidgetOptions
+
+// MarshalJSON encodes the options as a JSON string, as stored by Grist
+func (o *WidgetOptions) MarshalJSON() ([]byte, error) {
+	b, err := json.Marshal((*widgetOptionsFields)(o))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(o.Extra) > 0 {
+		var merged map[string]any
+		if err := json.Unmarshal(b, &merged); err != nil {
+			return nil, err
+		}
+		for k, v := range o.Extra {
+			if _, ok := merged[k]; !ok {
+				merged[k] = v
+			}
+		}
+		if b, err = json.Marshal(merged); err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(string(b))
+}
+
+// UnmarshalJSON decodes options stored either as a JSON string or as an object
+func (o *WidgetOptions) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		if s == "" {
+			*o = WidgetOptions{}
+			return nil
+		}
+		data = []byte(s)
+	}
+
+	var fields widgetOptionsFields
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return fmt.Errorf("invalid widget options: %w", err)
+	}
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return fmt.Errorf("invalid widget options: %w", err)
+	}
+
+	for _, k := range widgetOptionsKeys {
+		delete(all, k)
+	}
+	if len(all) > 0 {
+		fields.Extra = make(map[string]any, len(all))
+		for k, raw := range all {
+			var v any
+			if err := json.Unmarshal(raw, &v); err != nil {
+				return fmt.Errorf("invalid widget options: %w", err)
+			}
+			fields.Extra[k] = v
+		}
+	}
+
+	*o = WidgetOptions(fields)
+	return nil
+}
+
+// widgetOptionsKeys lists the JSON keys mapped to WidgetOptions fields
+var widgetOptionsKeys = func() []string {
+	t := reflect.TypeOf(WidgetOptions{})
+	keys := make([]string, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			keys = append(keys, name)
+		}
+	}
+	return keys
+}()
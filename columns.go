@@ -3,17 +3,257 @@ package grist
 import (
 	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/url"
 )
 
 type Columns struct {
-	Columns []Column
+	Columns []Column `json:"columns"`
 }
 
+// Column represents a column of a Grist table.
+// source: https://support.getgrist.com/api/#tag/columns
 type Column struct {
-	ID     string               `json:"id,omitempty"`
-	Label  string               `json:"label,omitempty"`
-	Type   string               `json:"type,omitempty"`
-	Fields map[string]CellValue `json:"fields,omitempty"`
+	ID     string       `json:"id,omitempty"`
+	Fields ColumnFields `json:"fields"`
+}
+
+// ColumnFields holds the column metadata sent and returned by the columns endpoints.
+// Pointer fields are only sent when set, so that their zero value can be applied.
+// source: https://support.getgrist.com/api/#tag/columns/operation/listColumns
+type ColumnFields struct {
+	// ColID renames the column when modifying it
+	ColID         string         `json:"colId,omitempty"`
+	Type          ColumnType     `json:"type,omitzero"`
+	Label         *string        `json:"label,omitempty"`
+	Description   *string        `json:"description,omitempty"`
+	Formula       *string        `json:"formula,omitempty"`
+	IsFormula     *bool          `json:"isFormula,omitempty"`
+	WidgetOptions *WidgetOptions `json:"widgetOptions,omitempty"`
+	// VisibleCol is the row ID of the column displayed by Ref and RefList
+	// columns, 0 shows the row ID
+	VisibleCol *int       `json:"visibleCol,omitempty"`
+	RecalcWhen *int       `json:"recalcWhen,omitempty"`
+	RecalcDeps ColumnRefs `json:"recalcDeps,omitempty"`
+	// UntieColIDFromLabel stops Grist from renaming the column when its label changes
+	UntieColIDFromLabel *bool `json:"untieColIdFromLabel,omitempty"`
+
+	// Read-only fields returned by ListColumns
+	ColRef     int `json:"colRef,omitempty"`
+	ParentID   int `json:"parentId,omitempty"`
+	DisplayCol int `json:"displayCol,omitempty"`
+}
+
+// Ptr returns a pointer to v, to set the optional fields of ColumnFields, e.g.
+//
+//	grist.ColumnFields{Label: grist.Ptr("Name"), Formula: grist.Ptr("")}
+func Ptr[T any](v T) *T {
+	return &v
+}
+
+// Values of ColumnFields.RecalcWhen
+// source: https://support.getgrist.com/api/#tag/columns/operation/addColumns
+const (
+	// RecalcWhenDefault recalculates on new records or when RecalcDeps change
+	RecalcWhenDefault = 0
+	// RecalcWhenNever never recalculates the formula
+	RecalcWhenNever = 1
+	// RecalcWhenManualUpdates recalculates on new records and on manual updates to any data field
+	RecalcWhenManualUpdates = 2
+)
+
+// ColumnRefs is a list of column row IDs, encoded by Grist as ["L", ...]
+type ColumnRefs []int
+
+func (r ColumnRefs) MarshalJSON() ([]byte, error) {
+	if r == nil {
+		return []byte("null"), nil
+	}
+	return json.Marshal(NewListCell(columnRefsCells(r)...))
+}
+
+func (r *ColumnRefs) UnmarshalJSON(data []byte) error {
+	var c CellValue
+	if err := json.Unmarshal(data, &c); err != nil {
+		return err
+	}
+	if c.Null {
+		*r = nil
+		return nil
+	}
+
+	items, ok := c.AsList()
+	if !ok {
+		return fmt.Errorf("invalid column refs: %s", string(data))
+	}
+	refs := make(ColumnRefs, 0, len(items))
+	for _, item := range items {
+		if item.Number == nil {
+			return fmt.Errorf("invalid column refs: %s", string(data))
+		}
+		refs = append(refs, int(*item.Number))
+	}
+	*r = refs
+	return nil
+}
+
+func columnRefsCells(refs ColumnRefs) []*CellValue {
+	cells := make([]*CellValue, 0, len(refs))
+	for _, ref := range refs {
+		cells = append(cells, NewNumberCell(float64(ref)))
+	}
+	return cells
+}
+
+// AddOrUpdateColumnsOptions holds the flags accepted by AddOrUpdateColumns
+type AddOrUpdateColumnsOptions struct {
+	// NoAdd prevents adding missing columns
+	NoAdd bool
+	// NoUpdate prevents updating existing columns
+	NoUpdate bool
+	// ReplaceAll removes the columns that are not in the request
+	ReplaceAll bool
+}
+
+func (o *AddOrUpdateColumnsOptions) values() url.Values {
+	values := url.Values{}
+	if o == nil {
+		return values
+	}
+	if o.NoAdd {
+		values.Set("noadd", "true")
+	}
+	if o.NoUpdate {
+		values.Set("noupdate", "true")
+	}
+	if o.ReplaceAll {
+		values.Set("replaceall", "true")
+	}
+	return values
+}
+
+func pathListColumns(docID, tableID string) string {
+	return pathListTables(docID) + "/" + tableID + "/columns"
+}
+
+func pathColumn(docID, tableID, colID string) string {
+	return pathListColumns(docID, tableID) + "/" + colID
+}
+
+// ListColumns lists the columns of a table, hidden includes hidden columns such as manualSort.
+// source: https://support.getgrist.com/api/#tag/columns/operation/listColumns
+func (d *Doc) ListColumns(c *Client, tableID string, hidden bool) (*Columns, error) {
+	endpoint := buildURL(c.ApiEndpoint(), pathListColumns(d.ID, tableID))
+	values := url.Values{}
+	if hidden {
+		values.Set("hidden", "true")
+	}
+
+	resp, err := c.GetRequest(
+		endpoint,
+		withAuth(c.ApiKey),
+		withQuery(values),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var columns Columns
+	if err := handleJSONResponse(resp, &columns, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &columns, nil
+}
+
+// AddColumns adds columns to a table and returns their IDs.
+// source: https://support.getgrist.com/api/#tag/columns/operation/addColumns
+func (d *Doc) AddColumns(c *Client, tableID string, obj Columns) (*Columns, error) {
+	endpoint := buildURL(c.ApiEndpoint(), pathListColumns(d.ID, tableID))
+	jsonBody, err := withJSONBody(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.PostRequest(
+		endpoint,
+		withAuth(c.ApiKey),
+		jsonBody,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var columns Columns
+	if err := handleJSONResponse(resp, &columns, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &columns, nil
+}
+
+// ModifyColumns updates existing columns of a table.
+// source: https://support.getgrist.com/api/#tag/columns/operation/modifyColumns
+func (d *Doc) ModifyColumns(c *Client, tableID string, obj Columns) error {
+	for _, col := range obj.Columns {
+		if col.ID == "" {
+			return fmt.Errorf("ModifyColumns: column id cannot be empty")
+		}
+	}
+
+	endpoint := buildURL(c.ApiEndpoint(), pathListColumns(d.ID, tableID))
+	jsonBody, err := withJSONBody(obj)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.PatchRequest(
+		endpoint,
+		withAuth(c.ApiKey),
+		jsonBody,
+	)
+	if err != nil {
+		return err
+	}
+	return handleStatus(resp, http.StatusOK)
+}
+
+// AddOrUpdateColumns adds missing columns and updates existing ones, opts may be nil.
+// source: https://support.getgrist.com/api/#tag/columns/operation/replaceColumns
+func (d *Doc) AddOrUpdateColumns(c *Client, tableID string, obj Columns, opts *AddOrUpdateColumnsOptions) error {
+	endpoint := buildURL(c.ApiEndpoint(), pathListColumns(d.ID, tableID))
+	jsonBody, err := withJSONBody(obj)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.DoRequest(
+		http.MethodPut,
+		endpoint,
+		withAuth(c.ApiKey),
+		withQuery(opts.values()),
+		jsonBody,
+	)
+	if err != nil {
+		return err
+	}
+	return handleStatus(resp, http.StatusOK)
+}
+
+// DeleteColumn removes a column from a table.
+// source: https://support.getgrist.com/api/#tag/columns/operation/deleteColumn
+func (d *Doc) DeleteColumn(c *Client, tableID, colID string) error {
+	if colID == "" {
+		return fmt.Errorf("DeleteColumn: column id cannot be empty")
+	}
+
+	endpoint := buildURL(c.ApiEndpoint(), pathColumn(d.ID, tableID, colID))
+	resp, err := c.DeleteRequest(
+		endpoint,
+		withAuth(c.ApiKey),
+	)
+	if err != nil {
+		return err
+	}
+	return handleStatus(resp, http.StatusOK)
 }
 
 type Field struct {
@@ -77,7 +317,6 @@ func (c *CellValue) UnmarshalJSON(data []byte) error {
 }
 
 func (c *CellValue) MarshalJSON() ([]byte, error) {
-	fmt.Println("MarshalJSON")
 	switch {
 	case c.Number != nil:
 		return json.Marshal(*c.Number)
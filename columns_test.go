@@ -0,0 +1,211 @@
+package grist
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDoc_ListColumns(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/docs/doc1/tables/Pets/columns", r.URL.Path)
+		assert.Equal(t, "true", r.URL.Query().Get("hidden"))
+		w.Write([]byte(`{"columns":[
+			{"id":"manualSort","fields":{"type":"ManualSortPos","isFormula":false,"recalcDeps":null}},
+			{"id":"Age","fields":{"type":"Int","label":"Age","isFormula":false,"recalcWhen":2,"recalcDeps":["L",2,5]}}
+		]}`))
+	}))
+	defer server.Close()
+
+	client, _ := NewGristClient(context.Background(), server.URL, "valid-key")
+	doc := &Doc{ID: "doc1"}
+	columns, err := doc.ListColumns(client, "Pets", true)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	assert.Len(t, columns.Columns, 2)
+	assert.Nil(t, columns.Columns[0].Fields.RecalcDeps)
+	age := columns.Columns[1].Fields
+	assert.Equal(t, TypeInt, age.Type)
+	assert.Equal(t, RecalcWhenManualUpdates, *age.RecalcWhen)
+	assert.Equal(t, ColumnRefs{2, 5}, age.RecalcDeps)
+}
+
+func TestParseColumnType(t *testing.T) {
+	t.Run("With parameters", func(t *testing.T) {
+		ct, err := ParseColumnType("Ref:People")
+		assert.NoError(t, err)
+		assert.Equal(t, TypeRef("People"), ct)
+
+		ct, err = ParseColumnType("DateTime:America/New_York")
+		assert.NoError(t, err)
+		assert.Equal(t, TypeDateTime("America/New_York"), ct)
+		assert.Equal(t, "DateTime:America/New_York", ct.String())
+	})
+	t.Run("With invalid table id returns error", func(t *testing.T) {
+		_, err := ParseColumnType("Ref:")
+		assert.EqualError(t, err, `invalid Ref column type: invalid table id ""`)
+	})
+	t.Run("With unexpected parameter returns error", func(t *testing.T) {
+		_, err := ParseColumnType("Text:People")
+		assert.Error(t, err)
+	})
+	t.Run("With unknown type decodes losslessly", func(t *testing.T) {
+		var ct ColumnType
+		assert.NoError(t, json.Unmarshal([]byte(`"ManualSortPos"`), &ct))
+		assert.False(t, ct.IsKnown())
+
+		b, err := json.Marshal(ct)
+		assert.NoError(t, err)
+		assert.Equal(t, `"ManualSortPos"`, string(b))
+	})
+}
+
+func TestWidgetOptions(t *testing.T) {
+	raw := `"{\"choices\":[\"a\",\"b\"],\"choiceOptions\":{\"a\":{\"fillColor\":\"#FF0000\"}},\"alignment\":\"left\",\"rulesOptions\":[]}"`
+
+	var opts WidgetOptions
+	if err := json.Unmarshal([]byte(raw), &opts); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	assert.Equal(t, []string{"a", "b"}, opts.Choices)
+	assert.Equal(t, "#FF0000", opts.ChoiceOptions["a"].FillColor)
+	assert.Equal(t, AlignLeft, opts.Alignment)
+	assert.Contains(t, opts.Extra, "rulesOptions")
+
+	b, err := json.Marshal(&opts)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	var s string
+	assert.NoError(t, json.Unmarshal(b, &s))
+	assert.JSONEq(t, `{"choices":["a","b"],"choiceOptions":{"a":{"fillColor":"#FF0000"}},"alignment":"left","rulesOptions":[]}`, s)
+}
+
+func TestDoc_AddColumns(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/api/docs/doc1/tables/Pets/columns", r.URL.Path)
+		body, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{"columns":[
+			{"id":"Name","fields":{"type":"Text","label":"Name"}},
+			{"id":"Owner","fields":{"type":"Ref:People","visibleCol":3}}
+		]}`, string(body))
+		w.Write([]byte(`{"columns":[{"id":"Name"},{"id":"Owner"}]}`))
+	}))
+	defer server.Close()
+
+	client, _ := NewGristClient(context.Background(), server.URL, "valid-key")
+	doc := &Doc{ID: "doc1"}
+	columns, err := doc.AddColumns(client, "Pets", Columns{Columns: []Column{
+		{ID: "Name", Fields: ColumnFields{Type: TypeText, Label: Ptr("Name")}},
+		{ID: "Owner", Fields: ColumnFields{Type: TypeRef("People"), VisibleCol: Ptr(3)}},
+	}})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	assert.Equal(t, []Column{{ID: "Name"}, {ID: "Owner"}}, columns.Columns)
+}
+
+func TestDoc_ModifyColumns(t *testing.T) {
+	t.Run("With cleared fields sends their zero value", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPatch, r.Method)
+			assert.Equal(t, "/api/docs/doc1/tables/Pets/columns", r.URL.Path)
+			body, _ := io.ReadAll(r.Body)
+			assert.JSONEq(t, `{"columns":[
+				{"id":"Age","fields":{"label":"","description":"","formula":"","isFormula":false,"visibleCol":0}}
+			]}`, string(body))
+		}))
+		defer server.Close()
+
+		client, _ := NewGristClient(context.Background(), server.URL, "valid-key")
+		doc := &Doc{ID: "doc1"}
+		err := doc.ModifyColumns(client, "Pets", Columns{Columns: []Column{
+			{ID: "Age", Fields: ColumnFields{
+				Label:       Ptr(""),
+				Description: Ptr(""),
+				Formula:     Ptr(""),
+				IsFormula:   Ptr(false),
+				VisibleCol:  Ptr(0),
+			}},
+		}})
+		assert.NoError(t, err)
+	})
+	t.Run("Without column id returns error", func(t *testing.T) {
+		client, _ := NewGristClient(context.Background(), "https://getgrist.com", "valid-key")
+		doc := &Doc{ID: "doc1"}
+		err := doc.ModifyColumns(client, "Pets", Columns{Columns: []Column{{Fields: ColumnFields{Label: Ptr("Age")}}}})
+		assert.EqualError(t, err, "ModifyColumns: column id cannot be empty")
+	})
+}
+
+func TestDoc_AddOrUpdateColumns(t *testing.T) {
+	tests := []struct {
+		name  string
+		opts  *AddOrUpdateColumnsOptions
+		query string
+	}{
+		{name: "Without options", opts: nil, query: ""},
+		{name: "With noadd", opts: &AddOrUpdateColumnsOptions{NoAdd: true}, query: "noadd=true"},
+		{name: "With noupdate", opts: &AddOrUpdateColumnsOptions{NoUpdate: true}, query: "noupdate=true"},
+		{name: "With replaceall", opts: &AddOrUpdateColumnsOptions{ReplaceAll: true}, query: "replaceall=true"},
+		{name: "With all flags", opts: &AddOrUpdateColumnsOptions{NoAdd: true, NoUpdate: true, ReplaceAll: true}, query: "noadd=true&noupdate=true&replaceall=true"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPut, r.Method)
+				assert.Equal(t, "/api/docs/doc1/tables/Pets/columns", r.URL.Path)
+				assert.Equal(t, tt.query, r.URL.RawQuery)
+				body, _ := io.ReadAll(r.Body)
+				assert.JSONEq(t, `{"columns":[{"id":"Name","fields":{"type":"Text"}}]}`, string(body))
+			}))
+			defer server.Close()
+
+			client, _ := NewGristClient(context.Background(), server.URL, "valid-key")
+			doc := &Doc{ID: "doc1"}
+			err := doc.AddOrUpdateColumns(client, "Pets", Columns{Columns: []Column{
+				{ID: "Name", Fields: ColumnFields{Type: TypeText}},
+			}}, tt.opts)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestDoc_DeleteColumn(t *testing.T) {
+	t.Run("Deletes the column", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodDelete, r.Method)
+			assert.Equal(t, "/api/docs/doc1/tables/Pets/columns/Age", r.URL.Path)
+		}))
+		defer server.Close()
+
+		client, _ := NewGristClient(context.Background(), server.URL, "valid-key")
+		doc := &Doc{ID: "doc1"}
+		assert.NoError(t, doc.DeleteColumn(client, "Pets", "Age"))
+	})
+	t.Run("With unknown column returns ErrNotFound", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":"Column not found \"Age\""}`))
+		}))
+		defer server.Close()
+
+		client, _ := NewGristClient(context.Background(), server.URL, "valid-key")
+		doc := &Doc{ID: "doc1"}
+		err := doc.DeleteColumn(client, "Pets", "Age")
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+	t.Run("Without column id returns error", func(t *testing.T) {
+		client, _ := NewGristClient(context.Background(), "https://getgrist.com", "valid-key")
+		doc := &Doc{ID: "doc1"}
+		assert.EqualError(t, doc.DeleteColumn(client, "Pets", ""), "DeleteColumn: column id cannot be empty")
+	})
+}
@@ -1,8 +1,11 @@
 package grist
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
+	"path/filepath"
+	"time"
 )
 
 type Doc struct {
@@ -12,6 +15,8 @@ type Doc struct {
 	IsPinned  bool       `json:"isPinned"`
 	UrlID     string     `json:"urlId,omitempty"`
 	Workspace Workspace  `json:"workspace,omitempty"`
+	// RemovedAt is set when the document is in the trash
+	RemovedAt *time.Time `json:"removedAt,omitempty"`
 }
 
 func pathDescribeDocs(docID string) string {
@@ -110,10 +115,113 @@ func (d *Doc) DeleteDoc(c *Client) error {
 	return nil
 }
 
-// ImportDoc, not yet implemented.
-// source: https://support.getgrist.com/api/#tag/docs/operation/importDoc
-func (ws *Workspace) ImportDoc(c *Client, url string) (*string, error) {
-	return nil, nil
+// Remove moves the document to the trash, see Unremove.
+// source: https://support.getgrist.com/api/#tag/docs/operation/removeDoc
+func (d *Doc) Remove(c *Client) error {
+	return trashRequest(c, pathDescribeDocs(d.ID)+"/remove")
+}
+
+// Unremove restores the document from the trash.
+// source: https://support.getgrist.com/api/#tag/docs/operation/unremoveDoc
+func (d *Doc) Unremove(c *Client) error {
+	return trashRequest(c, pathDescribeDocs(d.ID)+"/unremove")
+}
+
+// ImportOptions holds the parameters of ImportDoc, a nil ImportOptions imports
+// the file as a new document named after the file.
+type ImportOptions struct {
+	DocumentName string
+	// ReplaceDocID, when set, overwrites this existing document with the
+	// imported file instead of keeping a new document. This is not an additive
+	// import: every table, record and access rule of the document is replaced.
+	ReplaceDocID string
+}
+
+type importResult struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+}
+
+func pathWorkspaceImport(wsID int64) string {
+	return pathWorkspace(wsID) + "/import"
+}
+
+// ImportDoc uploads a .grist, .xlsx, .csv or .json file to the workspace and
+// returns the ID of the resulting document.
+// With ReplaceDocID, the whole content of the target document is overwritten
+// by the file, as with Doc.Replace: its tables, data and access rules are lost.
+// The file is first imported as a temporary document in the workspace, which
+// is removed once it has replaced the target.
+// source: https://support.getgrist.com/api/#tag/workspaces/operation/importDoc
+func (ws *Workspace) ImportDoc(c *Client, file FileUpload, opts *ImportOptions) (*string, error) {
+	if file.FileName == "" || file.Content == nil {
+		return nil, fmt.Errorf("ImportDoc: file name and content are required")
+	}
+	if filepath.Ext(file.FileName) == "" {
+		return nil, fmt.Errorf("ImportDoc: file name %q has no extension", file.FileName)
+	}
+	if opts == nil {
+		opts = &ImportOptions{}
+	}
+
+	fields := map[string]string{}
+	if opts.DocumentName != "" {
+		fields["documentName"] = opts.DocumentName
+	}
+
+	endpoint := buildURL(c.ApiEndpoint(), pathWorkspaceImport(ws.ID))
+	resp, err := c.PostRequest(
+		endpoint,
+		withAuth(c.ApiKey),
+		withMultipartForm(fields, "upload", []FileUpload{file}),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var result importResult
+	if err := handleJSONResponse(resp, &result, http.StatusOK); err != nil {
+		return nil, err
+	}
+	if result.ID == "" {
+		return nil, fmt.Errorf("ImportDoc: no document id returned")
+	}
+	if opts.ReplaceDocID == "" {
+		return &result.ID, nil
+	}
+
+	imported := &Doc{ID: result.ID}
+	target := &Doc{ID: opts.ReplaceDocID}
+	if err := target.replace(c, ReplaceSource{SourceDocID: imported.ID}); err != nil {
+		err = fmt.Errorf("ImportDoc: replace %s with imported document %s: %w", target.ID, imported.ID, err)
+		if delErr := imported.DeleteDoc(c); delErr != nil {
+			err = errors.Join(err, fmt.Errorf("ImportDoc: delete temporary document %s: %w", imported.ID, delErr))
+		}
+		return nil, err
+	}
+	// The target already holds the imported content, a temporary document
+	// left behind is not worth failing the import
+	_ = imported.DeleteDoc(c)
+	return &opts.ReplaceDocID, nil
+}
+
+// replace replaces the content of the document from another document or a snapshot
+func (d *Doc) replace(c *Client, source ReplaceSource) error {
+	endpoint := buildURL(c.ApiEndpoint(), pathDescribeDocs(d.ID)+"/replace")
+	jsonBody, err := withJSONBody(source)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.PostRequest(
+		endpoint,
+		withAuth(c.ApiKey),
+		jsonBody,
+	)
+	if err != nil {
+		return err
+	}
+	return handleStatus(resp, http.StatusOK)
 }
 
 // DescribeDoc fetches a document by ID.
@@ -135,3 +243,130 @@ func DescribeDoc(c *Client, docID string) (*Doc, error) {
 	}
 	return &doc, nil
 }
+
+// CopyOptions holds the parameters of Copy
+type CopyOptions struct {
+	// DocumentName is the name of the copy, required by Grist
+	DocumentName string
+	// AsTemplate copies the document structure without its data
+	AsTemplate bool
+}
+
+// ReplaceSource is the content Replace puts in the document, exactly one field must be set
+type ReplaceSource struct {
+	SourceDocID string `json:"sourceDocId,omitempty"`
+	SnapshotID  string `json:"snapshotId,omitempty"`
+}
+
+type forkResult struct {
+	ForkID string `json:"forkId"`
+	DocID  string `json:"docId"`
+	URLID  string `json:"urlId"`
+}
+
+// Copy copies the document into a workspace and returns the copy.
+// source: https://support.getgrist.com/api/#tag/docs/operation/copyDoc
+func (d *Doc) Copy(c *Client, workspaceID int64, opts CopyOptions) (*Doc, error) {
+	if workspaceID <= 0 {
+		return nil, fmt.Errorf("Copy: invalid workspace id: %d", workspaceID)
+	}
+	if opts.DocumentName == "" {
+		return nil, fmt.Errorf("Copy: document name cannot be empty")
+	}
+
+	endpoint := buildURL(c.ApiEndpoint(), pathDescribeDocs(d.ID)+"/copy")
+	bodyOpt, err := withJSONBody(struct {
+		WorkspaceID  int64  `json:"workspaceId"`
+		DocumentName string `json:"documentName"`
+		AsTemplate   bool   `json:"asTemplate,omitempty"`
+	}{
+		WorkspaceID:  workspaceID,
+		DocumentName: opts.DocumentName,
+		AsTemplate:   opts.AsTemplate,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.PostRequest(
+		endpoint,
+		withAuth(c.ApiKey),
+		bodyOpt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var id string
+	if err := handleJSONResponse(resp, &id, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return DescribeDoc(c, id)
+}
+
+// Fork creates a personal fork of the document. Forks are not listed in
+// workspaces, the returned Doc holds the fork IDs with the trunk metadata.
+// source: https://support.getgrist.com/api/#tag/docs/operation/forkDoc
+func (d *Doc) Fork(c *Client) (*Doc, error) {
+	endpoint := buildURL(c.ApiEndpoint(), pathDescribeDocs(d.ID)+"/fork")
+	resp, err := c.PostRequest(
+		endpoint,
+		withAuth(c.ApiKey),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var result forkResult
+	if err := handleJSONResponse(resp, &result, http.StatusOK); err != nil {
+		return nil, err
+	}
+
+	fork := *d
+	fork.ID = result.DocID
+	fork.UrlID = result.URLID
+	return &fork, nil
+}
+
+// Move moves the document to another workspace of the same org and returns it.
+// source: https://support.getgrist.com/api/#tag/docs/operation/moveDoc
+func (d *Doc) Move(c *Client, workspaceID int64) (*Doc, error) {
+	if workspaceID <= 0 {
+		return nil, fmt.Errorf("Move: invalid workspace id: %d", workspaceID)
+	}
+
+	endpoint := buildURL(c.ApiEndpoint(), pathDescribeDocs(d.ID)+"/move")
+	bodyOpt, err := withJSONBody(struct {
+		Workspace int64 `json:"workspace"`
+	}{Workspace: workspaceID})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.PatchRequest(
+		endpoint,
+		withAuth(c.ApiKey),
+		bodyOpt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := handleStatus(resp, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return DescribeDoc(c, d.ID)
+}
+
+// Replace replaces the content of the document with another document or one
+// of its snapshots, and returns the document.
+// source: https://support.getgrist.com/api/#tag/docs/operation/replaceDoc
+func (d *Doc) Replace(c *Client, source ReplaceSource) (*Doc, error) {
+	if (source.SourceDocID == "") == (source.SnapshotID == "") {
+		return nil, fmt.Errorf("Replace: exactly one of source doc id and snapshot id must be set")
+	}
+
+	if err := d.replace(c, source); err != nil {
+		return nil, err
+	}
+	return DescribeDoc(c, d.ID)
+}
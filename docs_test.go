@@ -0,0 +1,297 @@
+package grist
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkspace_ImportDoc(t *testing.T) {
+	t.Run("With new document returns its id", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/api/workspaces/3/import", r.URL.Path)
+			if err := r.ParseMultipartForm(1 << 20); err != nil {
+				t.Fatalf("Expected multipart body, got %v", err)
+			}
+			assert.Equal(t, "Pets", r.FormValue("documentName"))
+
+			f, header, err := r.FormFile("upload")
+			if err != nil {
+				t.Fatalf("Expected upload file, got %v", err)
+			}
+			content, _ := io.ReadAll(f)
+			assert.Equal(t, "pets.csv", header.Filename)
+			assert.Equal(t, "Name\nRex\n", string(content))
+			w.Write([]byte(`{"id":"newDoc","title":"Pets"}`))
+		}))
+		defer server.Close()
+
+		client, _ := NewGristClient(context.Background(), server.URL, "valid-key")
+		ws := &Workspace{ID: 3}
+		docID, err := ws.ImportDoc(client, FileUpload{FileName: "pets.csv", Content: strings.NewReader("Name\nRex\n")}, &ImportOptions{DocumentName: "Pets"})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		assert.Equal(t, "newDoc", *docID)
+	})
+	t.Run("With existing document replaces it", func(t *testing.T) {
+		var calls []string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls = append(calls, r.Method+" "+r.URL.Path)
+			switch r.URL.Path {
+			case "/api/workspaces/3/import":
+				w.Write([]byte(`{"id":"tmpDoc","title":"pets"}`))
+			case "/api/docs/target/replace":
+				body, _ := io.ReadAll(r.Body)
+				assert.JSONEq(t, `{"sourceDocId":"tmpDoc"}`, string(body))
+			}
+		}))
+		defer server.Close()
+
+		client, _ := NewGristClient(context.Background(), server.URL, "valid-key")
+		ws := &Workspace{ID: 3}
+		docID, err := ws.ImportDoc(client, FileUpload{FileName: "pets.grist", Content: strings.NewReader("sqlite")}, &ImportOptions{ReplaceDocID: "target"})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		assert.Equal(t, "target", *docID)
+		assert.Equal(t, []string{
+			"POST /api/workspaces/3/import",
+			"POST /api/docs/target/replace",
+			"DELETE /api/docs/tmpDoc",
+		}, calls)
+	})
+	t.Run("With existing document overwrites its content", func(t *testing.T) {
+		tables := map[string]string{
+			"target": `{"tables":[{"id":"Clients"},{"id":"Invoices"}]}`,
+		}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method + " " + r.URL.Path {
+			case "POST /api/workspaces/3/import":
+				tables["tmpDoc"] = `{"tables":[{"id":"Pets"}]}`
+				w.Write([]byte(`{"id":"tmpDoc","title":"pets"}`))
+			case "POST /api/docs/target/replace":
+				tables["target"] = tables["tmpDoc"]
+			case "DELETE /api/docs/tmpDoc":
+				delete(tables, "tmpDoc")
+			case "GET /api/docs/target/tables":
+				w.Write([]byte(tables["target"]))
+			default:
+				t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			}
+		}))
+		defer server.Close()
+
+		client, _ := NewGristClient(context.Background(), server.URL, "valid-key")
+		ws := &Workspace{ID: 3}
+		docID, err := ws.ImportDoc(client, FileUpload{FileName: "pets.grist", Content: strings.NewReader("sqlite")}, &ImportOptions{ReplaceDocID: "target"})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		target := &Doc{ID: *docID}
+		got, err := target.ListTables(client)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		var ids []string
+		for _, table := range got.Tables {
+			ids = append(ids, table.ID)
+		}
+		assert.Equal(t, []string{"Pets"}, ids)
+		assert.NotContains(t, tables, "tmpDoc")
+	})
+	t.Run("With failed replace deletes the temporary document", func(t *testing.T) {
+		var calls []string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls = append(calls, r.Method+" "+r.URL.Path)
+			switch r.URL.Path {
+			case "/api/workspaces/3/import":
+				w.Write([]byte(`{"id":"tmpDoc","title":"pets"}`))
+			case "/api/docs/target/replace":
+				w.WriteHeader(http.StatusInternalServerError)
+			}
+		}))
+		defer server.Close()
+
+		client, _ := NewGristClient(context.Background(), server.URL, "valid-key")
+		ws := &Workspace{ID: 3}
+		_, err := ws.ImportDoc(client, FileUpload{FileName: "pets.grist", Content: strings.NewReader("sqlite")}, &ImportOptions{ReplaceDocID: "target"})
+		assert.Error(t, err)
+		assert.Equal(t, []string{
+			"POST /api/workspaces/3/import",
+			"POST /api/docs/target/replace",
+			"DELETE /api/docs/tmpDoc",
+		}, calls)
+	})
+	t.Run("With failed cleanup after replace succeeds", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/api/workspaces/3/import":
+				w.Write([]byte(`{"id":"tmpDoc","title":"pets"}`))
+			case "/api/docs/tmpDoc":
+				w.WriteHeader(http.StatusForbidden)
+			}
+		}))
+		defer server.Close()
+
+		client, _ := NewGristClient(context.Background(), server.URL, "valid-key")
+		ws := &Workspace{ID: 3}
+		docID, err := ws.ImportDoc(client, FileUpload{FileName: "pets.grist", Content: strings.NewReader("sqlite")}, &ImportOptions{ReplaceDocID: "target"})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		assert.Equal(t, "target", *docID)
+	})
+	t.Run("Without file returns error", func(t *testing.T) {
+		client, _ := NewGristClient(context.Background(), "https://getgrist.com", "valid-key")
+		ws := &Workspace{ID: 3}
+		_, err := ws.ImportDoc(client, FileUpload{}, nil)
+		assert.EqualError(t, err, "ImportDoc: file name and content are required")
+	})
+}
+
+func TestDoc_Copy(t *testing.T) {
+	t.Run("Posts the copy and describes it", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method + " " + r.URL.Path {
+			case "POST /api/docs/doc1/copy":
+				body, _ := io.ReadAll(r.Body)
+				assert.JSONEq(t, `{"workspaceId":4,"documentName":"Pets copy","asTemplate":true}`, string(body))
+				w.Write([]byte(`"copy1"`))
+			case "GET /api/docs/copy1":
+				w.Write([]byte(`{"id":"copy1","name":"Pets copy"}`))
+			default:
+				t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			}
+		}))
+		defer server.Close()
+
+		client, _ := NewGristClient(context.Background(), server.URL, "valid-key")
+		doc := &Doc{ID: "doc1"}
+		cp, err := doc.Copy(client, 4, CopyOptions{DocumentName: "Pets copy", AsTemplate: true})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		assert.Equal(t, "copy1", cp.ID)
+		assert.Equal(t, "Pets copy", cp.Name)
+	})
+	t.Run("Without document name returns error", func(t *testing.T) {
+		client, _ := NewGristClient(context.Background(), "https://getgrist.com", "valid-key")
+		doc := &Doc{ID: "doc1"}
+		_, err := doc.Copy(client, 4, CopyOptions{})
+		assert.EqualError(t, err, "Copy: document name cannot be empty")
+	})
+}
+
+func TestDoc_Fork(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/api/docs/doc1/fork", r.URL.Path)
+		w.Write([]byte(`{"forkId":"f1","docId":"doc1~f1~5","urlId":"pets~f1~5"}`))
+	}))
+	defer server.Close()
+
+	client, _ := NewGristClient(context.Background(), server.URL, "valid-key")
+	doc := &Doc{ID: "doc1", Name: "Pets"}
+	fork, err := doc.Fork(client)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	assert.Equal(t, "doc1~f1~5", fork.ID)
+	assert.Equal(t, "pets~f1~5", fork.UrlID)
+	assert.Equal(t, "Pets", fork.Name)
+	assert.Equal(t, "doc1", doc.ID)
+}
+
+func TestDoc_Move(t *testing.T) {
+	var calls []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		switch r.URL.Path {
+		case "/api/docs/doc1/move":
+			body, _ := io.ReadAll(r.Body)
+			assert.JSONEq(t, `{"workspace":9}`, string(body))
+		case "/api/docs/doc1":
+			w.Write([]byte(`{"id":"doc1","workspace":{"id":9}}`))
+		}
+	}))
+	defer server.Close()
+
+	client, _ := NewGristClient(context.Background(), server.URL, "valid-key")
+	doc := &Doc{ID: "doc1"}
+	moved, err := doc.Move(client, 9)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	assert.Equal(t, int64(9), moved.Workspace.ID)
+	assert.Equal(t, []string{"PATCH /api/docs/doc1/move", "GET /api/docs/doc1"}, calls)
+}
+
+func TestDoc_Replace(t *testing.T) {
+	t.Run("With snapshot posts it", func(t *testing.T) {
+		var calls []string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls = append(calls, r.Method+" "+r.URL.Path)
+			switch r.URL.Path {
+			case "/api/docs/doc1/replace":
+				body, _ := io.ReadAll(r.Body)
+				assert.JSONEq(t, `{"snapshotId":"snap1"}`, string(body))
+			case "/api/docs/doc1":
+				w.Write([]byte(`{"id":"doc1"}`))
+			}
+		}))
+		defer server.Close()
+
+		client, _ := NewGristClient(context.Background(), server.URL, "valid-key")
+		doc := &Doc{ID: "doc1"}
+		_, err := doc.Replace(client, ReplaceSource{SnapshotID: "snap1"})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		assert.Equal(t, []string{"POST /api/docs/doc1/replace", "GET /api/docs/doc1"}, calls)
+	})
+	t.Run("With source document posts it", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/api/docs/doc1/replace" {
+				body, _ := io.ReadAll(r.Body)
+				assert.JSONEq(t, `{"sourceDocId":"doc2"}`, string(body))
+				return
+			}
+			w.Write([]byte(`{"id":"doc1"}`))
+		}))
+		defer server.Close()
+
+		client, _ := NewGristClient(context.Background(), server.URL, "valid-key")
+		doc := &Doc{ID: "doc1"}
+		_, err := doc.Replace(client, ReplaceSource{SourceDocID: "doc2"})
+		assert.NoError(t, err)
+	})
+	t.Run("With both or no source returns error", func(t *testing.T) {
+		client, _ := NewGristClient(context.Background(), "https://getgrist.com", "valid-key")
+		doc := &Doc{ID: "doc1"}
+		_, err := doc.Replace(client, ReplaceSource{})
+		assert.Error(t, err)
+		_, err = doc.Replace(client, ReplaceSource{SourceDocID: "doc2", SnapshotID: "snap1"})
+		assert.Error(t, err)
+	})
+}
+
+func TestDoc_RemoveAndUnremove(t *testing.T) {
+	var calls []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+	}))
+	defer server.Close()
+
+	client, _ := NewGristClient(context.Background(), server.URL, "valid-key")
+	doc := &Doc{ID: "doc1"}
+	assert.NoError(t, doc.Remove(client))
+	assert.NoError(t, doc.Unremove(client))
+	assert.Equal(t, []string{"POST /api/docs/doc1/remove", "POST /api/docs/doc1/unremove"}, calls)
+}
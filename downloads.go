@@ -0,0 +1,153 @@
+package grist
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// ExportHeader selects the column headers of exported tables
+type ExportHeader string
+
+const (
+	// ExportHeaderColID uses column IDs as headers
+	ExportHeaderColID ExportHeader = "colId"
+	// ExportHeaderLabel uses column labels as headers (Grist default)
+	ExportHeaderLabel ExportHeader = "label"
+)
+
+// DownloadOptions holds the parameters of document downloads, a nil
+// DownloadOptions uses Grist defaults.
+type DownloadOptions struct {
+	Header ExportHeader
+	// NoHistory leaves the action history out of the .grist file
+	NoHistory bool
+	// Template downloads the .grist file without its data
+	Template bool
+}
+
+func (o *DownloadOptions) values(tableID string) url.Values {
+	values := url.Values{}
+	if tableID != "" {
+		values.Set("tableId", tableID)
+	}
+	if o == nil {
+		return values
+	}
+	if o.Header != "" {
+		values.Set("header", string(o.Header))
+	}
+	if o.NoHistory {
+		values.Set("nohistory", "true")
+	}
+	if o.Template {
+		values.Set("template", "true")
+	}
+	return values
+}
+
+// TableSchema is a Frictionless data package describing an exported table.
+// source: https://specs.frictionlessdata.io/tabular-data-resource/
+type TableSchema struct {
+	Name      string `json:"name"`
+	Title     string `json:"title"`
+	Path      string `json:"path"`
+	Format    string `json:"format"`
+	MediaType string `json:"mediatype"`
+	Encoding  string `json:"encoding"`
+	Dialect   struct {
+		Delimiter        string `json:"delimiter"`
+		DoubleQuote      bool   `json:"doubleQuote"`
+		LineTerminator   string `json:"lineTerminator,omitempty"`
+		QuoteChar        string `json:"quoteChar,omitempty"`
+		SkipInitialSpace bool   `json:"skipInitialSpace,omitempty"`
+		Header           bool   `json:"header,omitempty"`
+	} `json:"dialect"`
+	Schema struct {
+		Fields []TableSchemaField `json:"fields"`
+	} `json:"schema"`
+}
+
+// TableSchemaField describes a column of an exported table
+type TableSchemaField struct {
+	Name        string `json:"name"`
+	Title       string `json:"title,omitempty"`
+	Type        string `json:"type"`
+	Format      string `json:"format,omitempty"`
+	Description string `json:"description,omitempty"`
+	Constraints *struct {
+		Enum []string `json:"enum,omitempty"`
+	} `json:"constraints,omitempty"`
+}
+
+func pathDownload(docID string) string {
+	return pathDescribeDocs(docID) + "/download"
+}
+
+func (d *Doc) download(c *Client, path string, values url.Values) (io.ReadCloser, error) {
+	endpoint := buildURL(c.ApiEndpoint(), path)
+	resp, err := c.GetRequest(
+		endpoint,
+		withAuth(c.ApiKey),
+		withQuery(values),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return handleStreamResponse(resp, http.StatusOK)
+}
+
+// Download streams the document as a .grist SQLite file. The caller must close the reader.
+// source: https://support.getgrist.com/api/#tag/docs/operation/downloadDoc
+func (d *Doc) Download(c *Client, opts *DownloadOptions) (io.ReadCloser, error) {
+	return d.download(c, pathDownload(d.ID), opts.values(""))
+}
+
+// DownloadXLSX streams the document as an Excel file, or a single table when
+// tableID is not empty. The caller must close the reader.
+// source: https://support.getgrist.com/api/#tag/docs/operation/downloadDocXlsx
+func (d *Doc) DownloadXLSX(c *Client, tableID string, opts *DownloadOptions) (io.ReadCloser, error) {
+	return d.download(c, pathDownload(d.ID)+"/xlsx", opts.values(tableID))
+}
+
+// DownloadCSV streams a table as CSV. The caller must close the reader.
+// source: https://support.getgrist.com/api/#tag/docs/operation/downloadDocCsv
+func (d *Doc) DownloadCSV(c *Client, tableID string, opts *DownloadOptions) (io.ReadCloser, error) {
+	if tableID == "" {
+		return nil, fmt.Errorf("DownloadCSV: table id cannot be empty")
+	}
+	return d.download(c, pathDownload(d.ID)+"/csv", opts.values(tableID))
+}
+
+// DownloadTSV streams a table as TSV. The caller must close the reader.
+func (d *Doc) DownloadTSV(c *Client, tableID string, opts *DownloadOptions) (io.ReadCloser, error) {
+	if tableID == "" {
+		return nil, fmt.Errorf("DownloadTSV: table id cannot be empty")
+	}
+	return d.download(c, pathDownload(d.ID)+"/tsv", opts.values(tableID))
+}
+
+// DownloadTableSchema returns the Frictionless schema of a table, as exported by DownloadCSV.
+// source: https://support.getgrist.com/api/#tag/docs/operation/downloadTableSchema
+func (d *Doc) DownloadTableSchema(c *Client, tableID string, opts *DownloadOptions) (*TableSchema, error) {
+	if tableID == "" {
+		return nil, fmt.Errorf("DownloadTableSchema: table id cannot be empty")
+	}
+
+	endpoint := buildURL(c.ApiEndpoint(), pathDownload(d.ID)+"/table-schema")
+	resp, err := c.GetRequest(
+		endpoint,
+		withAuth(c.ApiKey),
+		withQuery(opts.values(tableID)),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var schema TableSchema
+	if err := handleJSONResponse(resp, &schema, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &schema, nil
+}
@@ -0,0 +1,110 @@
+package grist
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDownloadOptions_values(t *testing.T) {
+	var nilOpts *DownloadOptions
+	assert.Equal(t, url.Values{}, nilOpts.values(""))
+	assert.Equal(t, url.Values{"tableId": {"Pets"}}, nilOpts.values("Pets"))
+
+	opts := &DownloadOptions{Header: ExportHeaderColID, NoHistory: true, Template: true}
+	assert.Equal(t, url.Values{
+		"tableId":   {"Pets"},
+		"header":    {"colId"},
+		"nohistory": {"true"},
+		"template":  {"true"},
+	}, opts.values("Pets"))
+	assert.Equal(t, url.Values{}, (&DownloadOptions{}).values(""))
+}
+
+func TestDoc_Download(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		requests = append(requests, r.URL.Path+"?"+r.URL.RawQuery)
+		w.Write([]byte("content"))
+	}))
+	defer server.Close()
+
+	client, _ := NewGristClient(context.Background(), server.URL, "valid-key")
+	doc := &Doc{ID: "doc1"}
+	opts := &DownloadOptions{Header: ExportHeaderLabel}
+
+	for _, download := range []func() (io.ReadCloser, error){
+		func() (io.ReadCloser, error) { return doc.Download(client, &DownloadOptions{NoHistory: true}) },
+		func() (io.ReadCloser, error) { return doc.DownloadXLSX(client, "", nil) },
+		func() (io.ReadCloser, error) { return doc.DownloadXLSX(client, "Pets", opts) },
+		func() (io.ReadCloser, error) { return doc.DownloadCSV(client, "Pets", opts) },
+		func() (io.ReadCloser, error) { return doc.DownloadTSV(client, "Pets", nil) },
+	} {
+		rc, err := download()
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		content, _ := io.ReadAll(rc)
+		rc.Close()
+		assert.Equal(t, "content", string(content))
+	}
+
+	assert.Equal(t, []string{
+		"/api/docs/doc1/download?nohistory=true",
+		"/api/docs/doc1/download/xlsx?",
+		"/api/docs/doc1/download/xlsx?header=label&tableId=Pets",
+		"/api/docs/doc1/download/csv?header=label&tableId=Pets",
+		"/api/docs/doc1/download/tsv?tableId=Pets",
+	}, requests)
+
+	_, err := doc.DownloadCSV(client, "", nil)
+	assert.Error(t, err)
+	_, err = doc.DownloadTSV(client, "", nil)
+	assert.Error(t, err)
+}
+
+func TestDoc_DownloadTableSchema(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/docs/doc1/download/table-schema", r.URL.Path)
+		assert.Equal(t, "Pets", r.URL.Query().Get("tableId"))
+		w.Write([]byte(`{
+			"name": "pets",
+			"title": "Pets",
+			"path": "https://grist.test/api/docs/doc1/download/csv?tableId=Pets",
+			"format": "csv",
+			"mediatype": "text/csv",
+			"encoding": "utf-8",
+			"dialect": {"delimiter": ",", "doubleQuote": true},
+			"schema": {"fields": [
+				{"name": "Name", "title": "Name", "type": "string"},
+				{"name": "Kind", "type": "string", "constraints": {"enum": ["cat", "dog"]}},
+				{"name": "Born", "type": "date", "format": "YYYY-MM-DD"}
+			]}
+		}`))
+	}))
+	defer server.Close()
+
+	client, _ := NewGristClient(context.Background(), server.URL, "valid-key")
+	doc := &Doc{ID: "doc1"}
+	schema, err := doc.DownloadTableSchema(client, "Pets", nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	assert.Equal(t, "pets", schema.Name)
+	assert.Equal(t, "text/csv", schema.MediaType)
+	assert.Equal(t, ",", schema.Dialect.Delimiter)
+	assert.True(t, schema.Dialect.DoubleQuote)
+	assert.Len(t, schema.Schema.Fields, 3)
+	assert.Equal(t, []string{"cat", "dog"}, schema.Schema.Fields[1].Constraints.Enum)
+	assert.Nil(t, schema.Schema.Fields[0].Constraints)
+	assert.Equal(t, "YYYY-MM-DD", schema.Schema.Fields[2].Format)
+
+	_, err = doc.DownloadTableSchema(client, "", nil)
+	assert.Error(t, err)
+}
@@ -1,19 +1,153 @@
 package grist
 
 import (
-	"fmt"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
 )
 
+// Sentinel errors matched by APIError with errors.Is, e.g.
+//
+//	if errors.Is(err, grist.ErrNotFound) { ... }
+var (
+	ErrBadRequest   = errors.New("bad request")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
+	ErrNotFound     = errors.New("not found")
+	ErrConflict     = errors.New("conflict")
+	ErrRateLimited  = errors.New("rate limited")
+)
+
+// ErrorForStatus returns the sentinel error matching an HTTP status code, nil if there is none
+func ErrorForStatus(statusCode int) error {
+	switch statusCode {
+	case http.StatusBadRequest:
+		return ErrBadRequest
+	case http.StatusUnauthorized:
+		return ErrUnauthorized
+	case http.StatusForbidden:
+		return ErrForbidden
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusConflict:
+		return ErrConflict
+	case http.StatusTooManyRequests:
+		return ErrRateLimited
+	default:
+		return nil
+	}
+}
+
+// ErrorDetails is the "details" field of Grist's JSON error body
+type ErrorDetails struct {
+	// UserError is a message meant to be shown to the user
+	UserError string `json:"userError,omitempty"`
+	// AccessMode is set on access errors, e.g. "fork"
+	AccessMode string     `json:"accessMode,omitempty"`
+	Memos      []string   `json:"memos,omitempty"`
+	Tips       []ErrorTip `json:"tips,omitempty"`
+}
+
+// ErrorTip suggests an action to solve the error, e.g. "upgrade" or "ask-for-help"
+type ErrorTip struct {
+	Action  string `json:"action"`
+	Message string `json:"message"`
+}
+
 // APIError represents a non-2xx HTTP response.
 type APIError struct {
 	StatusCode int
 	Status     string
 	Body       string
+	// Message is the "error" field of Grist's JSON error body, if any
+	// (e.g. "Invalid query: unknown column 'Foo'").
+	Message string
+	// Details is the "details" field of Grist's JSON error body, if any
+	Details *ErrorDetails
+
+	// Method and URL describe the failed request, secrets are redacted from the URL
+	Method string
+	URL    string
+	// RequestID is the X-Request-Id header of the response, if any
+	RequestID string
 }
 
 func (e *APIError) Error() string {
-	if e.Body == "" {
-		return fmt.Sprintf("api error: %s", e.Status)
+	msg := "api error: "
+	if e.Method != "" && e.URL != "" {
+		msg += e.Method + " " + e.URL + ": "
+	}
+	msg += e.Status
+
+	switch {
+	case e.Message != "":
+		msg += " - " + e.Message
+	case e.Body != "":
+		msg += " - " + e.Body
+	}
+	if e.RequestID != "" {
+		msg += " (request id " + e.RequestID + ")"
+	}
+	return msg
+}
+
+// Is matches the sentinel error of the status code, see ErrorForStatus
+func (e *APIError) Is(target error) bool {
+	sentinel := ErrorForStatus(e.StatusCode)
+	return sentinel != nil && sentinel == target
+}
+
+// newAPIError builds an APIError from a response and its already read body
+func newAPIError(resp *http.Response, body []byte) *APIError {
+	body = bytes.TrimSpace(body)
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		Body:       string(body),
+		RequestID:  resp.Header.Get("X-Request-Id"),
+	}
+	if resp.Request != nil {
+		apiErr.Method = resp.Request.Method
+		if resp.Request.URL != nil {
+			apiErr.URL = redactURL(resp.Request.URL)
+		}
+		if apiErr.RequestID == "" {
+			apiErr.RequestID = resp.Request.Header.Get("X-Request-Id")
+		}
+	}
+
+	var payload struct {
+		Error   string        `json:"error"`
+		Details *ErrorDetails `json:"details"`
+	}
+	if err := json.Unmarshal(body, &payload); err == nil {
+		apiErr.Message = payload.Error
+		apiErr.Details = payload.Details
+	}
+	return apiErr
+}
+
+// secretParams are query parameters whose value is redacted from error URLs
+var secretParams = []string{"auth", "key", "token", "secret", "password", "signature"}
+
+// redactURL returns u without its password and secret query values
+func redactURL(u *url.URL) string {
+	redacted := *u
+	if len(u.RawQuery) > 0 {
+		q := u.Query()
+		for k := range q {
+			lower := strings.ToLower(k)
+			for _, s := range secretParams {
+				if strings.Contains(lower, s) {
+					q[k] = []string{"xxxxx"}
+					break
+				}
+			}
+		}
+		redacted.RawQuery = q.Encode()
 	}
-	return fmt.Sprintf("api error: %s - %s", e.Status, e.Body)
+	return redacted.Redacted()
 }
@@ -75,10 +75,10 @@ func DocsExample() {
 			{
 				ID: "Contributors",
 				Columns: []grist.Column{
-					{ID: "name", Label: "Name", Type: "Text"},
-					{ID: "surname", Label: "Surname", Type: "Text"},
-					{ID: "contributions", Label: "Contributions", Type: "Numeric"},
-					{ID: "active", Label: "Active", Type: "Boolean"},
+					{ID: "name", Fields: grist.ColumnFields{Label: grist.Ptr("Name"), Type: grist.TypeText}},
+					{ID: "surname", Fields: grist.ColumnFields{Label: grist.Ptr("Surname"), Type: grist.TypeText}},
+					{ID: "contributions", Fields: grist.ColumnFields{Label: grist.Ptr("Contributions"), Type: grist.TypeNumeric}},
+					{ID: "active", Fields: grist.ColumnFields{Label: grist.Ptr("Active"), Type: grist.TypeBool}},
 				},
 			},
 		},
@@ -95,7 +95,7 @@ func DocsExample() {
 		return
 	}
 
-	records, err := doc.ListRecords(gc, newTables.Tables[len(newTables.Tables)-1].ID)
+	records, err := doc.ListRecords(gc, newTables.Tables[len(newTables.Tables)-1].ID, &grist.QueryOptions{Limit: 10})
 	if err != nil {
 		fmt.Printf("error listing records: %v: ", err)
 		return
@@ -0,0 +1,274 @@
+// Package gristsql provides a read-only database/sql driver backed by the
+// Grist SQL endpoint.
+//
+//	db, err := sql.Open("grist", "grist://apikey@docs.getgrist.com/docId")
+//	rows, err := db.QueryContext(ctx, "SELECT Name, Age FROM Pets WHERE Age > ?", 2)
+//
+// Only SELECT statements are accepted, transactions and Exec return errors.
+package gristsql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/quentinchampenois/go-grist-api"
+)
+
+// DriverName is the name the driver is registered with
+const DriverName = "grist"
+
+// ErrReadOnly is returned for statements and transactions that would modify the document
+var ErrReadOnly = errors.New("gristsql: connection is read-only")
+
+func init() {
+	sql.Register(DriverName, &Driver{})
+}
+
+// Driver implements driver.Driver and driver.DriverContext
+type Driver struct{}
+
+// Open opens a connection from a DSN, see ParseDSN
+func (d *Driver) Open(dsn string) (driver.Conn, error) {
+	connector, err := d.OpenConnector(dsn)
+	if err != nil {
+		return nil, err
+	}
+	return connector.Connect(context.Background())
+}
+
+// OpenConnector parses the DSN once for every connection of a sql.DB
+func (d *Driver) OpenConnector(dsn string) (driver.Connector, error) {
+	cfg, err := ParseDSN(dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := grist.NewGristClient(context.Background(), cfg.Endpoint, cfg.APIKey)
+	if err != nil {
+		return nil, err
+	}
+	connector := NewConnector(client, cfg.DocID)
+	connector.timeout = cfg.Timeout
+	return connector, nil
+}
+
+// Connector implements driver.Connector on top of an existing grist.Client
+type Connector struct {
+	client  *grist.Client
+	doc     *grist.Doc
+	timeout time.Duration
+
+	schemaMu sync.Mutex
+	schema   map[string]grist.ColumnType
+}
+
+// NewConnector returns a connector querying docID, to be used with sql.OpenDB
+func NewConnector(c *grist.Client, docID string) *Connector {
+	return &Connector{
+		client: c,
+		doc:    &grist.Doc{ID: docID},
+	}
+}
+
+// Connect returns a new connection, connections are stateless HTTP clients
+func (c *Connector) Connect(context.Context) (driver.Conn, error) {
+	return &conn{connector: c}, nil
+}
+
+// Driver returns the grist driver
+func (c *Connector) Driver() driver.Driver {
+	return &Driver{}
+}
+
+// columnTypes returns the Grist type of every column name of the document.
+// Names used by several tables with different types are left out, their
+// values are converted from the inferred type. The schema is loaded once it
+// succeeds, a failure only disables type reporting for the current query.
+func (c *Connector) columnTypes(ctx context.Context) map[string]grist.ColumnType {
+	c.schemaMu.Lock()
+	defer c.schemaMu.Unlock()
+	if c.schema != nil {
+		return c.schema
+	}
+
+	schema, err := c.loadSchema(ctx)
+	if err != nil {
+		return nil
+	}
+	c.schema = schema
+	return schema
+}
+
+// loadSchema lists the columns of every table of the document, hidden ones
+// included so that a name shared with a helper column is detected as ambiguous
+func (c *Connector) loadSchema(ctx context.Context) (map[string]grist.ColumnType, error) {
+	client := *c.client
+	client.Context = ctx
+
+	tables, err := c.doc.ListTables(&client)
+	if err != nil {
+		return nil, err
+	}
+
+	schema := map[string]grist.ColumnType{}
+	ambiguous := map[string]bool{}
+	for _, table := range tables.Tables {
+		columns, err := c.doc.ListColumns(&client, table.ID, true)
+		if err != nil {
+			return nil, err
+		}
+		for _, col := range columns.Columns {
+			if t, ok := schema[col.ID]; ok && t != col.Fields.Type {
+				ambiguous[col.ID] = true
+				continue
+			}
+			schema[col.ID] = col.Fields.Type
+		}
+	}
+	for name := range ambiguous {
+		delete(schema, name)
+	}
+	schema["id"] = grist.TypeInt
+	return schema, nil
+}
+
+type conn struct {
+	connector *Connector
+}
+
+var (
+	_ driver.QueryerContext     = (*conn)(nil)
+	_ driver.ConnBeginTx        = (*conn)(nil)
+	_ driver.NamedValueChecker  = (*conn)(nil)
+	_ driver.ConnPrepareContext = (*conn)(nil)
+)
+
+func (c *conn) Prepare(query string) (driver.Stmt, error) {
+	return c.PrepareContext(context.Background(), query)
+}
+
+func (c *conn) PrepareContext(_ context.Context, query string) (driver.Stmt, error) {
+	if err := checkReadOnly(query); err != nil {
+		return nil, err
+	}
+	return &stmt{conn: c, query: query}, nil
+}
+
+func (c *conn) Close() error {
+	return nil
+}
+
+func (c *conn) Begin() (driver.Tx, error) {
+	return nil, ErrReadOnly
+}
+
+func (c *conn) BeginTx(context.Context, driver.TxOptions) (driver.Tx, error) {
+	return nil, ErrReadOnly
+}
+
+// CheckNamedValue converts time.Time arguments to Grist timestamps and rejects named arguments
+func (c *conn) CheckNamedValue(nv *driver.NamedValue) error {
+	if nv.Name != "" {
+		return fmt.Errorf("gristsql: named arguments are not supported (%s)", nv.Name)
+	}
+	if t, ok := nv.Value.(time.Time); ok {
+		nv.Value = float64(t.UnixNano()) / float64(time.Second)
+		return nil
+	}
+
+	v, err := driver.DefaultParameterConverter.ConvertValue(nv.Value)
+	if err != nil {
+		return err
+	}
+	nv.Value = v
+	return nil
+}
+
+func (c *conn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	if err := checkReadOnly(query); err != nil {
+		return nil, err
+	}
+
+	values := make([]any, 0, len(args))
+	for _, arg := range args {
+		if b, ok := arg.Value.([]byte); ok {
+			values = append(values, string(b))
+			continue
+		}
+		values = append(values, arg.Value)
+	}
+
+	result, err := c.connector.doc.RunSQL(ctx, c.connector.client, grist.SQLQuery{
+		SQL:     query,
+		Args:    values,
+		Timeout: c.connector.timeout,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newRows(result, c.connector.columnTypes(ctx)), nil
+}
+
+type stmt struct {
+	conn  *conn
+	query string
+}
+
+var _ driver.StmtQueryContext = (*stmt)(nil)
+
+func (s *stmt) Close() error {
+	return nil
+}
+
+// NumInput returns -1, the number of parameters is checked by Grist
+func (s *stmt) NumInput() int {
+	return -1
+}
+
+func (s *stmt) Exec([]driver.Value) (driver.Result, error) {
+	return nil, ErrReadOnly
+}
+
+func (s *stmt) Query(args []driver.Value) (driver.Rows, error) {
+	named := make([]driver.NamedValue, 0, len(args))
+	for i, arg := range args {
+		named = append(named, driver.NamedValue{Ordinal: i + 1, Value: arg})
+	}
+	return s.QueryContext(context.Background(), named)
+}
+
+func (s *stmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	return s.conn.QueryContext(ctx, s.query, args)
+}
+
+// checkReadOnly accepts statements starting with SELECT or WITH, after comments
+func checkReadOnly(query string) error {
+	q := strings.TrimSpace(query)
+	for {
+		switch {
+		case strings.HasPrefix(q, "--"):
+			_, rest, _ := strings.Cut(q, "\n")
+			q = strings.TrimSpace(rest)
+		case strings.HasPrefix(q, "/*"):
+			_, rest, _ := strings.Cut(q, "*/")
+			q = strings.TrimSpace(rest)
+		default:
+			end := strings.IndexFunc(q, func(r rune) bool { return !unicode.IsLetter(r) })
+			if end < 0 {
+				end = len(q)
+			}
+			keyword := strings.ToUpper(q[:end])
+			if keyword == "SELECT" || keyword == "WITH" {
+				return nil
+			}
+			return ErrReadOnly
+		}
+	}
+}
@@ -0,0 +1,206 @@
+package gristsql
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/quentinchampenois/go-grist-api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDSN(t *testing.T) {
+	t.Run("With full dsn", func(t *testing.T) {
+		cfg, err := ParseDSN("grist://secret@grist.example.com/o/team/doc1?timeout=2s")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		assert.Equal(t, "https://grist.example.com/o/team", cfg.Endpoint)
+		assert.Equal(t, "secret", cfg.APIKey)
+		assert.Equal(t, "doc1", cfg.DocID)
+		assert.Equal(t, 2*time.Second, cfg.Timeout)
+	})
+	t.Run("With tls disabled", func(t *testing.T) {
+		cfg, err := ParseDSN("grist://secret@localhost:8484/doc1?tls=false")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		assert.Equal(t, "http://localhost:8484", cfg.Endpoint)
+	})
+	t.Run("With missing parts returns error", func(t *testing.T) {
+		_, err := ParseDSN("grist://localhost/doc1")
+		assert.EqualError(t, err, "gristsql: dsn is missing the API key")
+
+		_, err = ParseDSN("grist://secret@localhost/")
+		assert.EqualError(t, err, "gristsql: dsn is missing the document id")
+
+		_, err = ParseDSN("postgres://secret@localhost/doc1")
+		assert.Error(t, err)
+	})
+}
+
+func TestDriver(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/docs/doc1/tables":
+			w.Write([]byte(`{"tables":[{"id":"Pets","fields":{"tableRef":1,"onDemand":false}}]}`))
+		case "/api/docs/doc1/tables/Pets/columns":
+			w.Write([]byte(`{"columns":[
+				{"id":"Name","fields":{"type":"Text"}},
+				{"id":"Born","fields":{"type":"Date"}},
+				{"id":"Owner","fields":{"type":"Ref:People"}}
+			]}`))
+		case "/api/docs/doc1/sql":
+			w.Write([]byte(`{"statement":"select","records":[
+				{"fields":{"Name":"Rex","Born":1583280000,"Owner":2}}
+			]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	host := strings.TrimPrefix(server.URL, "http://")
+	db, err := sql.Open(DriverName, "grist://secret@"+host+"/doc1?tls=false")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer db.Close()
+
+	t.Run("QueryRow scans typed values", func(t *testing.T) {
+		var (
+			name  string
+			born  time.Time
+			owner int64
+		)
+		err := db.QueryRow("SELECT Name, Born, Owner FROM Pets WHERE id = ?", 1).Scan(&name, &born, &owner)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		assert.Equal(t, "Rex", name)
+		assert.Equal(t, time.Date(2020, 3, 4, 0, 0, 0, 0, time.UTC), born)
+		assert.Equal(t, int64(2), owner)
+	})
+	t.Run("Column types come from the document schema", func(t *testing.T) {
+		rows, err := db.Query("SELECT Name, Born, Owner FROM Pets")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		defer rows.Close()
+
+		types, err := rows.ColumnTypes()
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		assert.Equal(t, "Text", types[0].DatabaseTypeName())
+		assert.Equal(t, "Date", types[1].DatabaseTypeName())
+		assert.Equal(t, "Ref:People", types[2].DatabaseTypeName())
+	})
+	t.Run("Write statements are rejected", func(t *testing.T) {
+		_, err := db.Exec("DELETE FROM Pets")
+		assert.ErrorIs(t, err, ErrReadOnly)
+
+		_, err = db.Begin()
+		assert.ErrorIs(t, err, ErrReadOnly)
+
+		for _, q := range []string{"DELETE\nFROM Pets", "UPDATE\tPets SET Name = 'x'", "SELECTED", "-- SELECT\nDROP TABLE Pets", ""} {
+			assert.ErrorIs(t, checkReadOnly(q), ErrReadOnly, q)
+		}
+	})
+	t.Run("Read statements are accepted whatever the whitespace", func(t *testing.T) {
+		for _, q := range []string{
+			"SELECT\nName FROM Pets",
+			"SELECT\tName FROM Pets",
+			"select Name from Pets",
+			"WITH(SELECT 1) SELECT 1",
+			"WITH\r\nx AS (SELECT 1) SELECT * FROM x",
+			"/* report */ SELECT 1",
+			"-- report\nSELECT 1",
+		} {
+			assert.NoError(t, checkReadOnly(q), q)
+		}
+	})
+}
+
+func TestConnector_columnTypes(t *testing.T) {
+	t.Run("With failed load retries on the next query", func(t *testing.T) {
+		failures := 1
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/api/docs/doc1/tables":
+				if failures > 0 {
+					failures--
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				w.Write([]byte(`{"tables":[{"id":"Pets"}]}`))
+			case "/api/docs/doc1/tables/Pets/columns":
+				assert.Equal(t, "true", r.URL.Query().Get("hidden"))
+				w.Write([]byte(`{"columns":[{"id":"Age","fields":{"type":"Int"}}]}`))
+			}
+		}))
+		defer server.Close()
+
+		client, _ := grist.NewGristClient(context.Background(), server.URL, "valid-key")
+		connector := NewConnector(client, "doc1")
+		assert.Nil(t, connector.columnTypes(context.Background()))
+		assert.Equal(t, map[string]grist.ColumnType{"Age": grist.TypeInt, "id": grist.TypeInt}, connector.columnTypes(context.Background()))
+	})
+	t.Run("With canceled context does not load the schema", func(t *testing.T) {
+		var calls int
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			w.Write([]byte(`{"tables":[]}`))
+		}))
+		defer server.Close()
+
+		client, _ := grist.NewGristClient(context.Background(), server.URL, "valid-key")
+		connector := NewConnector(client, "doc1")
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		assert.Nil(t, connector.columnTypes(ctx))
+		assert.Equal(t, 0, calls)
+	})
+	t.Run("With ambiguous name keeps the inferred type", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/api/docs/doc1/tables":
+				w.Write([]byte(`{"tables":[{"id":"Pets"},{"id":"People"}]}`))
+			case "/api/docs/doc1/tables/Pets/columns":
+				w.Write([]byte(`{"columns":[{"id":"Age","fields":{"type":"Int"}},{"id":"Born","fields":{"type":"Date"}}]}`))
+			case "/api/docs/doc1/tables/People/columns":
+				w.Write([]byte(`{"columns":[{"id":"Age","fields":{"type":"Numeric"}},{"id":"Born","fields":{"type":"Text"}}]}`))
+			case "/api/docs/doc1/sql":
+				w.Write([]byte(`{"statement":"select","records":[{"fields":{"Age":2.5,"Born":1583280000}}]}`))
+			}
+		}))
+		defer server.Close()
+
+		client, _ := grist.NewGristClient(context.Background(), server.URL, "valid-key")
+		db := sql.OpenDB(NewConnector(client, "doc1"))
+		defer db.Close()
+
+		rows, err := db.Query("SELECT Age, Born FROM People")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		defer rows.Close()
+
+		types, err := rows.ColumnTypes()
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		assert.Equal(t, "Numeric", types[0].DatabaseTypeName())
+		assert.Equal(t, "Numeric", types[1].DatabaseTypeName())
+
+		var age, born any
+		assert.True(t, rows.Next())
+		assert.NoError(t, rows.Scan(&age, &born))
+		assert.Equal(t, 2.5, age)
+		assert.Equal(t, float64(1583280000), born)
+	})
+}
@@ -0,0 +1,75 @@
+package gristsql
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Config holds the connection settings parsed from a DSN
+type Config struct {
+	// Endpoint is the base URL of the Grist instance (e.g. https://docs.getgrist.com)
+	Endpoint string
+	APIKey   string
+	DocID    string
+	// Timeout is the server side timeout of each query, 0 uses Grist default
+	Timeout time.Duration
+}
+
+// ParseDSN parses a DSN of the form
+//
+//	grist://apikey@host[:port][/prefix]/docId[?tls=false&timeout=5s]
+//
+// tls=false connects over plain HTTP, which is useful for local instances.
+func ParseDSN(dsn string) (*Config, error) {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("gristsql: invalid dsn: %w", err)
+	}
+	if u.Scheme != DriverName {
+		return nil, fmt.Errorf("gristsql: invalid dsn scheme %q, expected %q", u.Scheme, DriverName)
+	}
+	if u.User == nil || u.User.Username() == "" {
+		return nil, fmt.Errorf("gristsql: dsn is missing the API key")
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("gristsql: dsn is missing the host")
+	}
+
+	path := strings.Trim(u.Path, "/")
+	prefix, docID := "", path
+	if i := strings.LastIndex(path, "/"); i >= 0 {
+		prefix, docID = "/"+path[:i], path[i+1:]
+	}
+	if docID == "" {
+		return nil, fmt.Errorf("gristsql: dsn is missing the document id")
+	}
+
+	scheme := "https"
+	q := u.Query()
+	if v := q.Get("tls"); v != "" {
+		useTLS, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("gristsql: invalid tls value %q", v)
+		}
+		if !useTLS {
+			scheme = "http"
+		}
+	}
+
+	cfg := &Config{
+		Endpoint: scheme + "://" + u.Host + prefix,
+		APIKey:   u.User.Username(),
+		DocID:    docID,
+	}
+	if v := q.Get("timeout"); v != "" {
+		timeout, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, fmt.Errorf("gristsql: invalid timeout value %q", v)
+		}
+		cfg.Timeout = timeout
+	}
+	return cfg, nil
+}
@@ -0,0 +1,155 @@
+package gristsql
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"io"
+	"math"
+	"reflect"
+	"time"
+
+	"github.com/quentinchampenois/go-grist-api"
+)
+
+var (
+	scanTypeInt64   = reflect.TypeOf(int64(0))
+	scanTypeFloat64 = reflect.TypeOf(float64(0))
+	scanTypeBool    = reflect.TypeOf(false)
+	scanTypeString  = reflect.TypeOf("")
+	scanTypeTime    = reflect.TypeOf(time.Time{})
+	scanTypeAny     = reflect.TypeOf((*any)(nil)).Elem()
+)
+
+type rows struct {
+	columns []string
+	types   []grist.ColumnType
+	records []grist.Record
+	pos     int
+}
+
+var (
+	_ driver.RowsColumnTypeDatabaseTypeName = (*rows)(nil)
+	_ driver.RowsColumnTypeScanType         = (*rows)(nil)
+	_ driver.RowsColumnTypeNullable         = (*rows)(nil)
+)
+
+func newRows(result *grist.SQLResult, schema map[string]grist.ColumnType) *rows {
+	r := &rows{
+		columns: result.Columns,
+		types:   make([]grist.ColumnType, len(result.Columns)),
+		records: result.Records,
+	}
+	for i, name := range result.Columns {
+		if t, ok := schema[name]; ok {
+			r.types[i] = t
+			continue
+		}
+		r.types[i] = inferType(result.Records, name)
+	}
+	return r
+}
+
+// inferType guesses the type of a column not found in the schema from its first non-null value
+func inferType(records []grist.Record, name string) grist.ColumnType {
+	for _, rec := range records {
+		c := rec.Fields[name]
+		switch {
+		case c == nil || c.Null:
+			continue
+		case c.Number != nil:
+			return grist.TypeNumeric
+		case c.String != nil:
+			return grist.TypeText
+		case c.Boolean != nil:
+			return grist.TypeBool
+		default:
+			return grist.TypeAny
+		}
+	}
+	return grist.TypeAny
+}
+
+func (r *rows) Columns() []string {
+	return r.columns
+}
+
+func (r *rows) Close() error {
+	r.pos = len(r.records)
+	return nil
+}
+
+func (r *rows) Next(dest []driver.Value) error {
+	if r.pos >= len(r.records) {
+		return io.EOF
+	}
+	rec := r.records[r.pos]
+	r.pos++
+
+	for i, name := range r.columns {
+		v, err := driverValue(rec.Fields[name], r.types[i])
+		if err != nil {
+			return err
+		}
+		dest[i] = v
+	}
+	return nil
+}
+
+// ColumnTypeDatabaseTypeName returns the Grist column type, e.g. "Int" or "Ref:People"
+func (r *rows) ColumnTypeDatabaseTypeName(index int) string {
+	return r.types[index].String()
+}
+
+func (r *rows) ColumnTypeScanType(index int) reflect.Type {
+	switch r.types[index].Kind {
+	case grist.KindInt, grist.KindRef:
+		return scanTypeInt64
+	case grist.KindNumeric:
+		return scanTypeFloat64
+	case grist.KindBool:
+		return scanTypeBool
+	case grist.KindDate, grist.KindDateTime:
+		return scanTypeTime
+	case grist.KindText, grist.KindChoice, grist.KindChoiceList, grist.KindRefList, grist.KindAttachments:
+		return scanTypeString
+	default:
+		return scanTypeAny
+	}
+}
+
+// ColumnTypeNullable reports every column as nullable, Grist cells may always be empty
+func (r *rows) ColumnTypeNullable(int) (bool, bool) {
+	return true, true
+}
+
+// driverValue converts a cell into a driver.Value according to the column type
+func driverValue(c *grist.CellValue, t grist.ColumnType) (driver.Value, error) {
+	switch {
+	case c == nil || c.Null:
+		return nil, nil
+	case c.String != nil:
+		return *c.String, nil
+	case c.Boolean != nil:
+		return *c.Boolean, nil
+	case c.Number != nil:
+		n := *c.Number
+		switch t.Kind {
+		case grist.KindInt, grist.KindRef:
+			if n == math.Trunc(n) {
+				return int64(n), nil
+			}
+		case grist.KindBool:
+			return n != 0, nil
+		case grist.KindDate, grist.KindDateTime:
+			sec, frac := math.Modf(n)
+			return time.Unix(int64(sec), int64(math.Round(frac*float64(time.Second)))).UTC(), nil
+		}
+		return n, nil
+	default:
+		b, err := json.Marshal(c)
+		if err != nil {
+			return nil, err
+		}
+		return string(b), nil
+	}
+}
@@ -89,13 +89,17 @@ func Clean() {
 	org := orgs[0]
 
 	workspaces, err := grist.ListWorkspaces(gc, org.ID)
+	if err != nil {
+		panic(err)
+	}
 	for _, ws := range workspaces {
-		err = ws.Delete(gc)
+		// Workspaces go to the trash and can be restored from the Grist UI
+		err = ws.Remove(gc)
 		if err != nil {
 			panic(err)
 		}
 
-		fmt.Printf("Workspace '%s' deleted.", ws.Name)
+		fmt.Printf("Workspace '%s' moved to trash.\n", ws.Name)
 	}
 
 	os.RemoveAll(BinaryName)
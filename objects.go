@@ -0,0 +1,320 @@
+package grist
+
+import (
+	"math"
+	"time"
+)
+
+// Grist object codes, the first item of an encoded object cell.
+// source: https://support.getgrist.com/code/enums/GristData.GristObjCode/
+const (
+	ObjCodeList           = "L"
+	ObjCodeLookUp         = "l"
+	ObjCodeDict           = "O"
+	ObjCodeDateTime       = "D"
+	ObjCodeDate           = "d"
+	ObjCodeSkip           = "S"
+	ObjCodeCensored       = "C"
+	ObjCodeReference      = "R"
+	ObjCodeReferenceList  = "r"
+	ObjCodeException      = "E"
+	ObjCodePending        = "P"
+	ObjCodeUnmarshallable = "U"
+	ObjCodeVersions       = "V"
+)
+
+// CellError is the content of a cell whose formula raised an exception
+type CellError struct {
+	Name    string
+	Message string
+	Details map[string]any
+}
+
+func (e *CellError) Error() string {
+	if e.Message == "" {
+		return e.Name
+	}
+	return e.Name + ": " + e.Message
+}
+
+// NewNumberCell returns a numeric CellValue
+func NewNumberCell(n float64) *CellValue {
+	return &CellValue{Number: &n}
+}
+
+// NewStringCell returns a text CellValue
+func NewStringCell(s string) *CellValue {
+	return &CellValue{String: &s}
+}
+
+// NewBoolCell returns a boolean CellValue
+func NewBoolCell(b bool) *CellValue {
+	return &CellValue{Boolean: &b}
+}
+
+// NewNullCell returns an empty CellValue
+func NewNullCell() *CellValue {
+	return &CellValue{Null: true}
+}
+
+func newObjectCell(code string, data ...any) *CellValue {
+	if data == nil {
+		data = []any{}
+	}
+	return &CellValue{Object: &ObjectGrist{Code: code, Data: data}}
+}
+
+// NewListCell returns a list CellValue (["L", ...])
+func NewListCell(items ...*CellValue) *CellValue {
+	data := make([]any, 0, len(items))
+	for _, item := range items {
+		data = append(data, item.value())
+	}
+	return newObjectCell(ObjCodeList, data...)
+}
+
+// NewDictCell returns a dict CellValue (["O", {...}])
+func NewDictCell(items map[string]*CellValue) *CellValue {
+	dict := make(map[string]any, len(items))
+	for k, item := range items {
+		dict[k] = item.value()
+	}
+	return newObjectCell(ObjCodeDict, dict)
+}
+
+// NewDateTimeCell returns a datetime CellValue (["D", timestamp, tz])
+func NewDateTimeCell(t time.Time, tz string) *CellValue {
+	return newObjectCell(ObjCodeDateTime, timeToGrist(t, false), tz)
+}
+
+// NewDateCell returns a date CellValue (["d", timestamp]), t is truncated to midnight UTC
+func NewDateCell(t time.Time) *CellValue {
+	return newObjectCell(ObjCodeDate, timeToGrist(t, true))
+}
+
+// NewReferenceCell returns a reference CellValue (["R", tableID, rowID])
+func NewReferenceCell(tableID string, rowID int) *CellValue {
+	return newObjectCell(ObjCodeReference, tableID, float64(rowID))
+}
+
+// NewReferenceListCell returns a reference list CellValue (["r", tableID, [rowIDs]])
+func NewReferenceListCell(tableID string, rowIDs []int) *CellValue {
+	ids := make([]any, 0, len(rowIDs))
+	for _, id := range rowIDs {
+		ids = append(ids, float64(id))
+	}
+	return newObjectCell(ObjCodeReferenceList, tableID, ids)
+}
+
+// NewErrorCell returns an exception CellValue (["E", name, message])
+func NewErrorCell(name, message string) *CellValue {
+	if message == "" {
+		return newObjectCell(ObjCodeException, name)
+	}
+	return newObjectCell(ObjCodeException, name, message)
+}
+
+// NewPendingCell returns a pending CellValue (["P"])
+func NewPendingCell() *CellValue {
+	return newObjectCell(ObjCodePending)
+}
+
+// NewCensoredCell returns a censored CellValue (["C"])
+func NewCensoredCell() *CellValue {
+	return newObjectCell(ObjCodeCensored)
+}
+
+// NewSkipCell returns a skip CellValue (["S"])
+func NewSkipCell() *CellValue {
+	return newObjectCell(ObjCodeSkip)
+}
+
+// NewUnmarshallableCell returns an unmarshallable CellValue (["U", repr])
+func NewUnmarshallableCell(repr string) *CellValue {
+	return newObjectCell(ObjCodeUnmarshallable, repr)
+}
+
+// NewVersionsCell returns a versions CellValue (["V", versions])
+func NewVersionsCell(versions any) *CellValue {
+	return newObjectCell(ObjCodeVersions, versions)
+}
+
+// IsObject reports whether the cell is an object with the given code
+func (c *CellValue) IsObject(code string) bool {
+	return c != nil && c.Object != nil && c.Object.Code == code
+}
+
+// IsPending reports whether the cell value is still being computed
+func (c *CellValue) IsPending() bool {
+	return c.IsObject(ObjCodePending)
+}
+
+// IsCensored reports whether the cell value is hidden by access rules
+func (c *CellValue) IsCensored() bool {
+	return c.IsObject(ObjCodeCensored)
+}
+
+// IsSkip reports whether the cell is a skip marker
+func (c *CellValue) IsSkip() bool {
+	return c.IsObject(ObjCodeSkip)
+}
+
+// AsList returns the items of a list cell
+func (c *CellValue) AsList() ([]*CellValue, bool) {
+	if !c.IsObject(ObjCodeList) {
+		return nil, false
+	}
+	items := make([]*CellValue, 0, len(c.Object.Data))
+	for _, item := range c.Object.Data {
+		items = append(items, cellFromValue(item))
+	}
+	return items, true
+}
+
+// AsDict returns the items of a dict cell
+func (c *CellValue) AsDict() (map[string]*CellValue, bool) {
+	if !c.IsObject(ObjCodeDict) || len(c.Object.Data) < 1 {
+		return nil, false
+	}
+	dict, ok := c.Object.Data[0].(map[string]any)
+	if !ok {
+		return nil, false
+	}
+	items := make(map[string]*CellValue, len(dict))
+	for k, v := range dict {
+		items[k] = cellFromValue(v)
+	}
+	return items, true
+}
+
+// AsDateTime returns the time of a datetime cell in tz. A nil tz uses the
+// cell timezone when it is known locally, UTC otherwise.
+func (c *CellValue) AsDateTime(tz *time.Location) (time.Time, bool) {
+	if !c.IsObject(ObjCodeDateTime) || len(c.Object.Data) < 1 {
+		return time.Time{}, false
+	}
+	ts, ok := c.Object.Data[0].(float64)
+	if !ok {
+		return time.Time{}, false
+	}
+
+	t := timeFromGrist(ts)
+	if tz == nil {
+		name, _ := c.DateTimeZone()
+		if loc, err := time.LoadLocation(name); name != "" && err == nil {
+			tz = loc
+		} else {
+			tz = time.UTC
+		}
+	}
+	return t.In(tz), true
+}
+
+// DateTimeZone returns the timezone name of a datetime cell, e.g. "Europe/Paris"
+func (c *CellValue) DateTimeZone() (string, bool) {
+	if !c.IsObject(ObjCodeDateTime) {
+		return "", false
+	}
+	var tz string
+	if len(c.Object.Data) > 1 {
+		tz, _ = c.Object.Data[1].(string)
+	}
+	return tz, true
+}
+
+// AsDate returns the date of a date cell, at midnight UTC
+func (c *CellValue) AsDate() (time.Time, bool) {
+	if !c.IsObject(ObjCodeDate) || len(c.Object.Data) < 1 {
+		return time.Time{}, false
+	}
+	ts, ok := c.Object.Data[0].(float64)
+	if !ok {
+		return time.Time{}, false
+	}
+	return timeFromGrist(ts), true
+}
+
+// AsReference returns the table and row ID of a reference cell
+func (c *CellValue) AsReference() (string, int, bool) {
+	if !c.IsObject(ObjCodeReference) || len(c.Object.Data) < 2 {
+		return "", 0, false
+	}
+	tableID, ok := c.Object.Data[0].(string)
+	if !ok {
+		return "", 0, false
+	}
+	rowID, ok := rowIDFromValue(c.Object.Data[1])
+	if !ok {
+		return "", 0, false
+	}
+	return tableID, rowID, true
+}
+
+// AsReferenceList returns the table and row IDs of a reference list cell
+func (c *CellValue) AsReferenceList() (string, []int, bool) {
+	if !c.IsObject(ObjCodeReferenceList) || len(c.Object.Data) < 2 {
+		return "", nil, false
+	}
+	tableID, ok := c.Object.Data[0].(string)
+	if !ok {
+		return "", nil, false
+	}
+	items, ok := c.Object.Data[1].([]any)
+	if !ok {
+		return "", nil, false
+	}
+	rowIDs := make([]int, 0, len(items))
+	for _, item := range items {
+		id, ok := rowIDFromValue(item)
+		if !ok {
+			return "", nil, false
+		}
+		rowIDs = append(rowIDs, id)
+	}
+	return tableID, rowIDs, true
+}
+
+// AsError returns the exception held by an error cell
+func (c *CellValue) AsError() (*CellError, bool) {
+	if !c.IsObject(ObjCodeException) || len(c.Object.Data) < 1 {
+		return nil, false
+	}
+	name, ok := c.Object.Data[0].(string)
+	if !ok {
+		return nil, false
+	}
+
+	cellErr := &CellError{Name: name}
+	if len(c.Object.Data) > 1 {
+		cellErr.Message, _ = c.Object.Data[1].(string)
+	}
+	if len(c.Object.Data) > 2 {
+		cellErr.Details, _ = c.Object.Data[2].(map[string]any)
+	}
+	return cellErr, true
+}
+
+// AsUnmarshallable returns the representation of a value Grist could not marshal
+func (c *CellValue) AsUnmarshallable() (string, bool) {
+	if !c.IsObject(ObjCodeUnmarshallable) || len(c.Object.Data) < 1 {
+		return "", false
+	}
+	repr, ok := c.Object.Data[0].(string)
+	return repr, ok
+}
+
+// AsVersions returns the raw versions object of a versions cell
+func (c *CellValue) AsVersions() (any, bool) {
+	if !c.IsObject(ObjCodeVersions) || len(c.Object.Data) < 1 {
+		return nil, false
+	}
+	return c.Object.Data[0], true
+}
+
+func rowIDFromValue(v any) (int, bool) {
+	n, ok := v.(float64)
+	if !ok || n != math.Trunc(n) {
+		return 0, false
+	}
+	return int(n), true
+}
@@ -0,0 +1,118 @@
+package grist
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// roundTrip marshals a cell and decodes it back
+func roundTrip(t *testing.T, c *CellValue) (*CellValue, string) {
+	t.Helper()
+	b, err := json.Marshal(c)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	var out CellValue
+	if err := json.Unmarshal(b, &out); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	return &out, string(b)
+}
+
+func TestCellValue_Objects(t *testing.T) {
+	t.Run("List", func(t *testing.T) {
+		c, raw := roundTrip(t, NewListCell(NewStringCell("a"), NewNumberCell(2)))
+		assert.Equal(t, `["L","a",2]`, raw)
+
+		items, ok := c.AsList()
+		assert.True(t, ok)
+		assert.Equal(t, "a", *items[0].String)
+		assert.Equal(t, float64(2), *items[1].Number)
+	})
+	t.Run("List and dict with nil items", func(t *testing.T) {
+		_, raw := roundTrip(t, NewListCell(NewStringCell("a"), nil))
+		assert.Equal(t, `["L","a",null]`, raw)
+
+		_, raw = roundTrip(t, NewDictCell(map[string]*CellValue{"a": nil}))
+		assert.Equal(t, `["O",{"a":null}]`, raw)
+	})
+	t.Run("DateTime", func(t *testing.T) {
+		at := time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+		c, raw := roundTrip(t, NewDateTimeCell(at, "UTC"))
+		assert.Equal(t, `["D",1714979289,"UTC"]`, raw)
+
+		got, ok := c.AsDateTime(nil)
+		assert.True(t, ok)
+		assert.True(t, at.Equal(got))
+		tz, ok := c.DateTimeZone()
+		assert.True(t, ok)
+		assert.Equal(t, "UTC", tz)
+	})
+	t.Run("DateTime in a location", func(t *testing.T) {
+		at := time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+		c, _ := roundTrip(t, NewDateTimeCell(at, "America/New_York"))
+		loc := time.FixedZone("UTC+2", 2*60*60)
+
+		got, ok := c.AsDateTime(loc)
+		assert.True(t, ok)
+		assert.Equal(t, loc, got.Location())
+		assert.Equal(t, 9, got.Hour())
+		assert.True(t, at.Equal(got))
+
+		_, ok = NewStringCell("x").AsDateTime(loc)
+		assert.False(t, ok)
+	})
+	t.Run("Date", func(t *testing.T) {
+		c, raw := roundTrip(t, NewDateCell(time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)))
+		assert.Equal(t, `["d",1714953600]`, raw)
+
+		got, ok := c.AsDate()
+		assert.True(t, ok)
+		assert.Equal(t, time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC), got)
+	})
+	t.Run("Reference", func(t *testing.T) {
+		c, raw := roundTrip(t, NewReferenceCell("People", 4))
+		assert.Equal(t, `["R","People",4]`, raw)
+
+		table, rowID, ok := c.AsReference()
+		assert.True(t, ok)
+		assert.Equal(t, "People", table)
+		assert.Equal(t, 4, rowID)
+	})
+	t.Run("ReferenceList", func(t *testing.T) {
+		c, raw := roundTrip(t, NewReferenceListCell("People", []int{1, 2}))
+		assert.Equal(t, `["r","People",[1,2]]`, raw)
+
+		table, rowIDs, ok := c.AsReferenceList()
+		assert.True(t, ok)
+		assert.Equal(t, "People", table)
+		assert.Equal(t, []int{1, 2}, rowIDs)
+	})
+	t.Run("Error", func(t *testing.T) {
+		c, raw := roundTrip(t, NewErrorCell("ZeroDivisionError", "division by zero"))
+		assert.Equal(t, `["E","ZeroDivisionError","division by zero"]`, raw)
+
+		cellErr, ok := c.AsError()
+		assert.True(t, ok)
+		assert.Equal(t, "ZeroDivisionError: division by zero", cellErr.Error())
+	})
+	t.Run("Pending and censored", func(t *testing.T) {
+		c, raw := roundTrip(t, NewCensoredCell())
+		assert.Equal(t, `["C"]`, raw)
+		assert.True(t, c.IsCensored())
+		assert.False(t, c.IsPending())
+
+		c, _ = roundTrip(t, NewPendingCell())
+		assert.True(t, c.IsPending())
+	})
+	t.Run("Accessor on other type", func(t *testing.T) {
+		c := NewStringCell("x")
+		_, ok := c.AsList()
+		assert.False(t, ok)
+		_, _, ok = c.AsReference()
+		assert.False(t, ok)
+	})
+}
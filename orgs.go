@@ -1,13 +1,9 @@
 package grist
 
 import (
-	"bytes"
-	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
-	"strconv"
-	"time"
+	"net/url"
 )
 
 type Org struct {
@@ -20,185 +16,106 @@ type Org struct {
 	Access    AccessRole `json:"access"`
 }
 
+// ListOrgs lists the orgs the user has access to.
+// source: https://support.getgrist.com/api/#tag/orgs/operation/listOrgs
 func ListOrgs(c *Client) ([]Org, error) {
-	// Make the GET request
-	endpoint := c.ApiEndpoint() + "/orgs"
-
-	client := &http.Client{
-		Timeout: 10 * time.Second,
-	}
-	req, err := http.NewRequest(http.MethodGet, endpoint, nil)
-	if err != nil {
-		return nil, err
-	}
-	// Add custom headers
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.ApiKey))
-	resp, err := client.Do(req)
+	endpoint := buildURL(c.ApiEndpoint(), "/orgs")
+	resp, err := c.GetRequest(
+		endpoint,
+		withAuth(c.ApiKey),
+	)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close() // Important: Close the response body to avoid resource leaks
-	// Check the response status
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("request %s failed with status: %s", endpoint, resp.Status)
-	}
-	// Read the response body
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %v", err)
-	}
 
 	var orgs []Org
-	err = json.Unmarshal(body, &orgs)
-	if err != nil {
+	if err := handleJSONResponse(resp, &orgs, http.StatusOK); err != nil {
 		return nil, err
 	}
-
 	return orgs, nil
 }
 
+// DescribeOrg fetches an org by ID.
+// source: https://support.getgrist.com/api/#tag/orgs/operation/describeOrg
 func DescribeOrg(c *Client, orgId int64) (Org, error) {
 	var org Org
-	endpoint := c.ApiEndpoint() + "/orgs/" + strconv.FormatInt(orgId, 10)
-
-	client := &http.Client{
-		Timeout: 10 * time.Second,
-	}
-	req, err := http.NewRequest(http.MethodGet, endpoint, nil)
-	if err != nil {
-		return org, err
-	}
-	// Add custom headers
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.ApiKey))
-	resp, err := client.Do(req)
-	if err != nil {
-		return org, err
-	}
-	defer resp.Body.Close() // Important: Close the response body to avoid resource leaks
-	// Check the response status
-	if resp.StatusCode != http.StatusOK {
-		return org, fmt.Errorf("request %s failed with status: %s", endpoint, resp.Status)
-	}
-	// Read the response body
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return org, fmt.Errorf("failed to read response body: %v", err)
+	if orgId <= 0 {
+		return org, fmt.Errorf("invalid orgId: %d", orgId)
 	}
 
-	err = json.Unmarshal(body, &org)
+	endpoint := buildURL(c.ApiEndpoint(), pathOrg(orgId))
+	resp, err := c.GetRequest(
+		endpoint,
+		withAuth(c.ApiKey),
+	)
 	if err != nil {
 		return org, err
 	}
 
-	return org, nil
+	err = handleJSONResponse(resp, &org, http.StatusOK)
+	return org, err
 }
 
+// Modify updates an org's name.
+// source: https://support.getgrist.com/api/#tag/orgs/operation/modifyOrg
 func (o *Org) Modify(c *Client, name string) error {
-	endpoint := c.ApiEndpoint() + "/orgs/" + strconv.FormatInt(o.ID, 10)
-	client := &http.Client{
-		Timeout: 10 * time.Second,
+	if o == nil || o.ID <= 0 {
+		return fmt.Errorf("invalid org receiver")
 	}
-
-	newName := map[string]string{
-		"name": name,
+	if name == "" {
+		return fmt.Errorf("org name cannot be empty")
 	}
 
-	jsonBody, err := json.MarshalIndent(newName, "", "  ")
-	if err != nil {
-		return err
-	}
-	bodyReader := bytes.NewReader(jsonBody)
-	req, err := http.NewRequest(http.MethodPatch, endpoint, bodyReader)
+	endpoint := buildURL(c.ApiEndpoint(), pathOrg(o.ID))
+	bodyOpt, err := withJSONBody(struct {
+		Name string `json:"name"`
+	}{Name: name})
 	if err != nil {
 		return err
 	}
-	// Add custom headers
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.ApiKey))
-	req.Header.Set("Content-Type", "application/json")
-	resp, err := client.Do(req)
+
+	resp, err := c.PatchRequest(
+		endpoint,
+		withAuth(c.ApiKey),
+		bodyOpt,
+	)
 	if err != nil {
 		return err
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("request %s failed with status: %s", endpoint, resp.Status)
-	}
-
-	return nil
+	return handleStatus(resp, http.StatusOK)
 }
 
+// Delete removes an org and all its workspaces and documents. Grist requires
+// the org name in the path as a confirmation.
+// source: https://support.getgrist.com/api/#tag/orgs/operation/deleteOrg
 func (o *Org) Delete(c *Client) error {
-	endpoint := c.ApiEndpoint() + "/orgs/" + strconv.FormatInt(o.ID, 10) + "/" + o.Name
-	client := &http.Client{
-		Timeout: 10 * time.Second,
+	if o == nil || o.ID <= 0 {
+		return fmt.Errorf("invalid org receiver")
 	}
-
-	req, err := http.NewRequest(http.MethodDelete, endpoint, nil)
-	if err != nil {
-		return err
+	if o.Name == "" {
+		return fmt.Errorf("org name cannot be empty")
 	}
-	// Add custom headers
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.ApiKey))
-	req.Header.Set("Content-Type", "application/json")
-	resp, err := client.Do(req)
+
+	endpoint := buildURL(c.ApiEndpoint(), pathOrg(o.ID)+"/"+url.PathEscape(o.Name))
+	resp, err := c.DeleteRequest(
+		endpoint,
+		withAuth(c.ApiKey),
+	)
 	if err != nil {
 		return err
 	}
-	defer resp.Body.Close()
-
-	switch resp.StatusCode {
-	case http.StatusForbidden:
-		return fmt.Errorf("forbidden (status_code: %s)", resp.Status)
-	case http.StatusNotFound:
-		return fmt.Errorf("not Found (status_code: %s)", resp.Status)
-	default:
-		fmt.Println("request status:", resp.Status)
-	}
-
-	return nil
+	return handleStatus(resp, http.StatusOK)
 }
 
-// FIXME: GetUsersAccess depends on deprecated documentation at https://support.getgrist.com/api/#tag/orgs/operation/listOrgAccess
-// Open PR to update response documentation
+// GetUsersAccess lists the users having access to the org, see GetAccess.
 func (o *Org) GetUsersAccess(c *Client) ([]User, error) {
-	var (
-		accessUsers AccessUser
-	)
-	endpoint := c.ApiEndpoint() + "/orgs/" + strconv.FormatInt(o.ID, 10) + "/access"
-
-	client := &http.Client{
-		Timeout: 10 * time.Second,
-	}
-	req, err := http.NewRequest(http.MethodGet, endpoint, nil)
-	if err != nil {
-		return accessUsers.Users, err
+	if o == nil || o.ID <= 0 {
+		return nil, fmt.Errorf("invalid org receiver")
 	}
-	// Add custom headers
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.ApiKey))
-	resp, err := client.Do(req)
-	if err != nil {
-		return accessUsers.Users, err
-	}
-	defer resp.Body.Close() // Important: Close the response body to avoid resource leaks
-	// Check the response status
 
-	fmt.Println(endpoint)
-	fmt.Println(o.ID)
-	if resp.StatusCode != http.StatusOK {
-		return accessUsers.Users, fmt.Errorf("request %s failed with status: %s", endpoint, resp.Status)
-	}
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return accessUsers.Users, fmt.Errorf("failed to read response body: %v", err)
-	}
-
-	err = json.Unmarshal(body, &accessUsers)
+	access, err := o.GetAccess(c)
 	if err != nil {
-		fmt.Println(string(body))
-		return accessUsers.Users, err
+		return nil, err
 	}
-
-	return accessUsers.Users, nil
+	return access.Users, nil
 }
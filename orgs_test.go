@@ -0,0 +1,127 @@
+package grist
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// roundTripFunc lets tests replace the transport of the client
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestListOrgs(t *testing.T) {
+	t.Run("Uses the client transport", func(t *testing.T) {
+		client, _ := NewGristClient(context.Background(), "http://grist.test", "valid-key")
+		client.HTTPClient.Transport = roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			assert.Equal(t, "/api/orgs", r.URL.Path)
+			assert.Equal(t, "Bearer valid-key", r.Header.Get("Authorization"))
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader(`[{"id": 2, "name": "Personal", "access": "owners"}]`)),
+				Header:     http.Header{},
+			}, nil
+		})
+
+		orgs, err := ListOrgs(client)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		assert.Equal(t, []Org{{ID: 2, Name: "Personal", Access: AccessRoleOwner}}, orgs)
+	})
+	t.Run("With canceled context fails", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t.Error("Unexpected request")
+		}))
+		defer server.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		client, _ := NewGristClient(ctx, server.URL, "valid-key")
+		_, err := ListOrgs(client)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestDescribeOrg(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error": "organization not found"}`))
+	}))
+	defer server.Close()
+
+	client, _ := NewGristClient(context.Background(), server.URL, "valid-key")
+	_, err := DescribeOrg(client, 42)
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Expected APIError, got %v", err)
+	}
+	assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+	assert.Equal(t, "organization not found", apiErr.Message)
+}
+
+func TestOrg_Modify(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPatch, r.Method)
+		assert.Equal(t, "/api/orgs/2", r.URL.Path)
+		body, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{"name": "Team"}`, string(body))
+	}))
+	defer server.Close()
+
+	client, _ := NewGristClient(context.Background(), server.URL, "valid-key")
+	org := &Org{ID: 2}
+	assert.NoError(t, org.Modify(client, "Team"))
+	assert.Error(t, org.Modify(client, ""))
+}
+
+func TestOrg_Delete(t *testing.T) {
+	t.Run("Sends the org name", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodDelete, r.Method)
+			assert.Equal(t, "/api/orgs/2/My Team", r.URL.Path)
+		}))
+		defer server.Close()
+
+		client, _ := NewGristClient(context.Background(), server.URL, "valid-key")
+		org := &Org{ID: 2, Name: "My Team"}
+		assert.NoError(t, org.Delete(client))
+	})
+	t.Run("With unexpected status fails", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		client, _ := NewGristClient(context.Background(), server.URL, "valid-key")
+		org := &Org{ID: 2, Name: "Team"}
+		var apiErr *APIError
+		assert.ErrorAs(t, org.Delete(client), &apiErr)
+	})
+}
+
+func TestOrg_GetUsersAccess(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/orgs/2/access", r.URL.Path)
+		w.Write([]byte(`{"users": [{"id": 1, "name": "Ann", "email": "ann@example.com", "access": "owners"}]}`))
+	}))
+	defer server.Close()
+
+	client, _ := NewGristClient(context.Background(), server.URL, "valid-key")
+	org := &Org{ID: 2}
+	users, err := org.GetUsersAccess(client)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	assert.Equal(t, []User{{ID: 1, Name: "Ann", Email: "ann@example.com", Access: AccessRoleOwner}}, users)
+}
@@ -0,0 +1,116 @@
+package grist
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// SortKey is one key of a Grist sort specification.
+// source: https://support.getgrist.com/api/#tag/records/operation/listRecords
+type SortKey struct {
+	Column     string
+	Descending bool
+	// NaturalSort compares strings containing numbers naturally ("a2" < "a10")
+	NaturalSort bool
+	// EmptyFirst places empty values before the others
+	EmptyFirst bool
+	// ChoiceOrder orders Choice columns by the order of their choices
+	ChoiceOrder bool
+}
+
+// String formats the key as expected by Grist (e.g. "-age:naturalSort;emptyFirst")
+func (k SortKey) String() string {
+	s := k.Column
+	if k.Descending {
+		s = "-" + s
+	}
+
+	var flags []string
+	if k.ChoiceOrder {
+		flags = append(flags, "choiceOrder")
+	}
+	if k.NaturalSort {
+		flags = append(flags, "naturalSort")
+	}
+	if k.EmptyFirst {
+		flags = append(flags, "emptyFirst")
+	}
+	if len(flags) > 0 {
+		s += ":" + strings.Join(flags, ";")
+	}
+	return s
+}
+
+// QueryOptions holds the filter, sort and limit parameters accepted by Grist
+// listing endpoints such as records and attachments.
+type QueryOptions struct {
+	// Filter maps a column ID to the list of values allowed in that column
+	Filter map[string][]any
+	// Sort lists the sort keys, in order of priority
+	Sort []SortKey
+	// Limit is the maximum number of rows to return, 0 means no limit
+	Limit int
+	// Hidden includes hidden columns such as manualSort in the response
+	Hidden bool
+	// UseHeaders sends sort and limit as X-Sort and X-Limit headers instead of query parameters
+	UseHeaders bool
+}
+
+func (o *QueryOptions) sortString() string {
+	keys := make([]string, 0, len(o.Sort))
+	for _, k := range o.Sort {
+		keys = append(keys, k.String())
+	}
+	return strings.Join(keys, ",")
+}
+
+// requestOptions converts the query options into request options
+func (o *QueryOptions) requestOptions() ([]requestOption, error) {
+	if o == nil {
+		return nil, nil
+	}
+
+	for _, k := range o.Sort {
+		if k.Column == "" {
+			return nil, fmt.Errorf("sort key column cannot be empty")
+		}
+	}
+	if o.Limit < 0 {
+		return nil, fmt.Errorf("invalid limit: %d", o.Limit)
+	}
+
+	values := url.Values{}
+	var opts []requestOption
+
+	if len(o.Filter) > 0 {
+		b, err := json.Marshal(o.Filter)
+		if err != nil {
+			return nil, fmt.Errorf("encode filter: %w", err)
+		}
+		values.Set("filter", string(b))
+	}
+	if o.Hidden {
+		values.Set("hidden", "true")
+	}
+
+	if o.UseHeaders {
+		if len(o.Sort) > 0 {
+			opts = append(opts, withHeader("X-Sort", o.sortString()))
+		}
+		if o.Limit > 0 {
+			opts = append(opts, withHeader("X-Limit", strconv.Itoa(o.Limit)))
+		}
+	} else {
+		if len(o.Sort) > 0 {
+			values.Set("sort", o.sortString())
+		}
+		if o.Limit > 0 {
+			values.Set("limit", strconv.Itoa(o.Limit))
+		}
+	}
+
+	return append(opts, withQuery(values)), nil
+}
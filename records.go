@@ -3,23 +3,88 @@ package grist
 import (
 	"fmt"
 	"net/http"
+	"net/url"
 )
 
 type Records struct {
 	Records []Record `json:"records"`
 }
 
+// RecordWithRequire is a record used by AddOrUpdateRecords: Require holds the
+// fields identifying the record to update, Fields the values to set.
+// source: https://support.getgrist.com/api/#tag/records/operation/replaceRecords
+type RecordWithRequire struct {
+	Require map[string]*CellValue `json:"require"`
+	Fields  map[string]*CellValue `json:"fields,omitempty"`
+}
+
+type RecordsWithRequire struct {
+	Records []RecordWithRequire `json:"records"`
+}
+
+// OnMany tells AddOrUpdateRecords what to do when several records match a require
+type OnMany string
+
+const (
+	// OnManyFirst updates only the first matching record (Grist default)
+	OnManyFirst OnMany = "first"
+	// OnManyNone updates none of the matching records
+	OnManyNone OnMany = "none"
+	// OnManyAll updates all matching records
+	OnManyAll OnMany = "all"
+)
+
+// AddOrUpdateOptions holds the flags accepted by AddOrUpdateRecords
+type AddOrUpdateOptions struct {
+	OnMany OnMany
+	// NoAdd prevents adding records when no record matches
+	NoAdd bool
+	// NoUpdate prevents updating matching records
+	NoUpdate bool
+	// AllowEmptyRequire allows an empty require, which matches every record
+	AllowEmptyRequire bool
+}
+
+func (o *AddOrUpdateOptions) values() url.Values {
+	values := url.Values{}
+	if o == nil {
+		return values
+	}
+	if o.OnMany != "" {
+		values.Set("onmany", string(o.OnMany))
+	}
+	if o.NoAdd {
+		values.Set("noadd", "true")
+	}
+	if o.NoUpdate {
+		values.Set("noupdate", "true")
+	}
+	if o.AllowEmptyRequire {
+		values.Set("allow_empty_require", "true")
+	}
+	return values
+}
+
 func pathListRecods(docID, tableID string) string {
 	return pathDescribeDocs(docID) + "/tables/" + tableID + "/records"
 }
 
-// ListRecords lists records for a table.
+func pathDeleteRecords(docID, tableID string) string {
+	return pathDescribeDocs(docID) + "/tables/" + tableID + "/data/delete"
+}
+
+// ListRecords lists records for a table, opts may be nil to fetch every record.
 // https://support.getgrist.com/api/#tag/records/operation/listRecords
-func (d *Doc) ListRecords(c *Client, tableID string) (*Records, error) {
+func (d *Doc) ListRecords(c *Client, tableID string, opts *QueryOptions) (*Records, error) {
 	endpoint := buildURL(c.ApiEndpoint(), pathListRecods(d.ID, tableID))
+	queryOpts, err := opts.requestOptions()
+	if err != nil {
+		return nil, err
+	}
+
 	resp, err := c.GetRequest(
 		endpoint,
-		withAuth(c.ApiKey),
+		append([]requestOption{withAuth(c.ApiKey)}, queryOpts...)...,
 	)
 	if err != nil {
 		return nil, err
@@ -40,11 +105,7 @@ func (d *Doc) CreateRecords(c *Client, tableID string, obj Records) (*Records, e
 	endpoint := buildURL(c.ApiEndpoint(), pathListRecods(d.ID, tableID))
 
 	jsonBody, err := withJSONBody(obj)
-
-	fmt.Println("object")
-	fmt.Println(obj)
 	if err != nil {
-		fmt.Println(err)
 		return nil, err
 	}
 
@@ -66,3 +127,76 @@ func (d *Doc) CreateRecords(c *Client, tableID string, obj Records) (*Records, e
 
 	return &records, nil
 }
+
+// UpdateRecords modifies existing records in a table, every record must have an ID.
+// https://support.getgrist.com/api/#tag/records/operation/modifyRecords
+func (d *Doc) UpdateRecords(c *Client, tableID string, obj Records) error {
+	for _, r := range obj.Records {
+		if r.ID <= 0 {
+			return fmt.Errorf("UpdateRecords: invalid record id: %d", r.ID)
+		}
+	}
+
+	endpoint := buildURL(c.ApiEndpoint(), pathListRecods(d.ID, tableID))
+	jsonBody, err := withJSONBody(obj)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.PatchRequest(
+		endpoint,
+		withAuth(c.ApiKey),
+		jsonBody,
+	)
+	if err != nil {
+		return err
+	}
+	return handleStatus(resp, http.StatusOK)
+}
+
+// AddOrUpdateRecords adds records or updates those matching their require fields.
+// opts may be nil to use Grist defaults.
+// https://support.getgrist.com/api/#tag/records/operation/replaceRecords
+func (d *Doc) AddOrUpdateRecords(c *Client, tableID string, obj RecordsWithRequire, opts *AddOrUpdateOptions) error {
+	endpoint := buildURL(c.ApiEndpoint(), pathListRecods(d.ID, tableID))
+	jsonBody, err := withJSONBody(obj)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.DoRequest(
+		http.MethodPut,
+		endpoint,
+		withAuth(c.ApiKey),
+		withQuery(opts.values()),
+		jsonBody,
+	)
+	if err != nil {
+		return err
+	}
+	return handleStatus(resp, http.StatusOK)
+}
+
+// DeleteRecords removes the records with the given row IDs from a table.
+// https://support.getgrist.com/api/#tag/data/operation/deleteRows
+func (d *Doc) DeleteRecords(c *Client, tableID string, rowIDs []int) error {
+	if len(rowIDs) == 0 {
+		return fmt.Errorf("DeleteRecords: row ids cannot be empty")
+	}
+
+	endpoint := buildURL(c.ApiEndpoint(), pathDeleteRecords(d.ID, tableID))
+	jsonBody, err := withJSONBody(rowIDs)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.PostRequest(
+		endpoint,
+		withAuth(c.ApiKey),
+		jsonBody,
+	)
+	if err != nil {
+		return err
+	}
+	return handleStatus(resp, http.StatusOK)
+}
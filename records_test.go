@@ -0,0 +1,168 @@
+package grist
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDoc_ListRecords(t *testing.T) {
+	t.Run("With query options encodes parameters", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/api/docs/doc1/tables/Pets/records", r.URL.Path)
+			q := r.URL.Query()
+			assert.Equal(t, `{"pet":["cat","dog"]}`, q.Get("filter"))
+			assert.Equal(t, "pet,-age:naturalSort;emptyFirst", q.Get("sort"))
+			assert.Equal(t, "5", q.Get("limit"))
+			assert.Equal(t, "true", q.Get("hidden"))
+			w.Write([]byte(`{"records":[{"id":1,"fields":{"pet":"cat"}}]}`))
+		}))
+		defer server.Close()
+
+		client, err := NewGristClient(context.Background(), server.URL, "valid-key")
+		if err != nil {
+			t.Fatalf("Failed to create client: %v", err)
+		}
+
+		doc := &Doc{ID: "doc1"}
+		records, err := doc.ListRecords(client, "Pets", &QueryOptions{
+			Filter: map[string][]any{"pet": {"cat", "dog"}},
+			Sort: []SortKey{
+				{Column: "pet"},
+				{Column: "age", Descending: true, NaturalSort: true, EmptyFirst: true},
+			},
+			Limit:  5,
+			Hidden: true,
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		assert.Len(t, records.Records, 1)
+		assert.Equal(t, "cat", *records.Records[0].Fields["pet"].String)
+	})
+	t.Run("With headers sends X-Sort and X-Limit", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "-age", r.Header.Get("X-Sort"))
+			assert.Equal(t, "2", r.Header.Get("X-Limit"))
+			assert.Empty(t, r.URL.Query().Get("sort"))
+			w.Write([]byte(`{"records":[]}`))
+		}))
+		defer server.Close()
+
+		client, _ := NewGristClient(context.Background(), server.URL, "valid-key")
+		doc := &Doc{ID: "doc1"}
+		_, err := doc.ListRecords(client, "Pets", &QueryOptions{
+			Sort:       []SortKey{{Column: "age", Descending: true}},
+			Limit:      2,
+			UseHeaders: true,
+		})
+		assert.NoError(t, err)
+	})
+	t.Run("With unknown column returns structured error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":"Invalid query: unknown column 'foo'"}`))
+		}))
+		defer server.Close()
+
+		client, _ := NewGristClient(context.Background(), server.URL, "valid-key")
+		doc := &Doc{ID: "doc1"}
+		_, err := doc.ListRecords(client, "Pets", &QueryOptions{Sort: []SortKey{{Column: "foo"}}})
+
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("Expected *APIError, got %v", err)
+		}
+		assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+		assert.Equal(t, "Invalid query: unknown column 'foo'", apiErr.Message)
+	})
+}
+
+func TestDoc_UpdateRecords(t *testing.T) {
+	t.Run("Patches the records", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPatch, r.Method)
+			assert.Equal(t, "/api/docs/doc1/tables/Pets/records", r.URL.Path)
+			body, _ := io.ReadAll(r.Body)
+			assert.JSONEq(t, `{"records":[
+				{"id":1,"fields":{"name":"Rex","age":3}},
+				{"id":2,"fields":{"age":null}}
+			]}`, string(body))
+		}))
+		defer server.Close()
+
+		client, _ := NewGristClient(context.Background(), server.URL, "valid-key")
+		doc := &Doc{ID: "doc1"}
+		err := doc.UpdateRecords(client, "Pets", Records{Records: []Record{
+			{ID: 1, Fields: map[string]*CellValue{"name": NewStringCell("Rex"), "age": NewNumberCell(3)}},
+			{ID: 2, Fields: map[string]*CellValue{"age": NewNullCell()}},
+		}})
+		assert.NoError(t, err)
+	})
+	t.Run("With record without id returns error", func(t *testing.T) {
+		client, _ := NewGristClient(context.Background(), "https://getgrist.com", "valid-key")
+		doc := &Doc{ID: "doc1"}
+		err := doc.UpdateRecords(client, "Pets", Records{Records: []Record{
+			{ID: 1, Fields: map[string]*CellValue{"age": NewNumberCell(3)}},
+			{Fields: map[string]*CellValue{"age": NewNumberCell(4)}},
+		}})
+		assert.EqualError(t, err, "UpdateRecords: invalid record id: 0")
+	})
+}
+
+func TestDoc_AddOrUpdateRecords(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/api/docs/doc1/tables/Pets/records", r.URL.Path)
+		q := r.URL.Query()
+		assert.Equal(t, "all", q.Get("onmany"))
+		assert.Equal(t, "true", q.Get("noadd"))
+		assert.Empty(t, q.Get("noupdate"))
+
+		body, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{"records":[{"require":{"name":"Rex"},"fields":{"age":3}}]}`, string(body))
+		w.Write([]byte("null"))
+	}))
+	defer server.Close()
+
+	client, _ := NewGristClient(context.Background(), server.URL, "valid-key")
+	doc := &Doc{ID: "doc1"}
+	name := "Rex"
+	age := float64(3)
+	err := doc.AddOrUpdateRecords(client, "Pets", RecordsWithRequire{
+		Records: []RecordWithRequire{
+			{
+				Require: map[string]*CellValue{"name": {String: &name}},
+				Fields:  map[string]*CellValue{"age": {Number: &age}},
+			},
+		},
+	}, &AddOrUpdateOptions{OnMany: OnManyAll, NoAdd: true})
+	assert.NoError(t, err)
+}
+
+func TestDoc_DeleteRecords(t *testing.T) {
+	t.Run("With row ids posts them to data/delete", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "/api/docs/doc1/tables/Pets/data/delete", r.URL.Path)
+			body, _ := io.ReadAll(r.Body)
+			assert.JSONEq(t, `[1,2,3]`, string(body))
+		}))
+		defer server.Close()
+
+		client, _ := NewGristClient(context.Background(), server.URL, "valid-key")
+		doc := &Doc{ID: "doc1"}
+		assert.NoError(t, doc.DeleteRecords(client, "Pets", []int{1, 2, 3}))
+	})
+	t.Run("With no row ids returns error", func(t *testing.T) {
+		client, _ := NewGristClient(context.Background(), "https://getgrist.com", "valid-key")
+		doc := &Doc{ID: "doc1"}
+		err := doc.DeleteRecords(client, "Pets", nil)
+		assert.EqualError(t, err, "DeleteRecords: row ids cannot be empty")
+	})
+}
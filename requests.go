@@ -2,10 +2,13 @@ package grist
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"mime/multipart"
 	"net/http"
+	"net/url"
 )
 
 type requestOption func(*http.Request)
@@ -19,14 +22,60 @@ func withJSONBody(body any) (requestOption, error) {
 	if err := json.NewEncoder(&buf).Encode(body); err != nil {
 		return nil, err
 	}
+	payload := buf.Bytes()
 
+	// GetBody lets the body be replayed on redirects and retries
 	return func(r *http.Request) {
-		r.Body = io.NopCloser(&buf)
-		r.ContentLength = int64(buf.Len())
+		r.Body = io.NopCloser(bytes.NewReader(payload))
+		r.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(payload)), nil
+		}
+		r.ContentLength = int64(len(payload))
 		r.Header.Set("Content-Type", "application/json")
 	}, nil
 }
 
+// FileUpload is a file sent in a multipart/form-data body
+type FileUpload struct {
+	// FileName is sent to Grist, which relies on its extension to detect the file type
+	FileName string
+	Content  io.Reader
+}
+
+// withMultipartForm streams fields and files as a multipart/form-data body,
+// each file under fileField
+func withMultipartForm(fields map[string]string, fileField string, files []FileUpload) requestOption {
+	return func(r *http.Request) {
+		pr, pw := io.Pipe()
+		mw := multipart.NewWriter(pw)
+
+		go func() {
+			for k, v := range fields {
+				if err := mw.WriteField(k, v); err != nil {
+					pw.CloseWithError(err)
+					return
+				}
+			}
+			for _, f := range files {
+				part, err := mw.CreateFormFile(fileField, f.FileName)
+				if err != nil {
+					pw.CloseWithError(err)
+					return
+				}
+				if _, err := io.Copy(part, f.Content); err != nil {
+					pw.CloseWithError(err)
+					return
+				}
+			}
+			pw.CloseWithError(mw.Close())
+		}()
+
+		r.Body = pr
+		r.ContentLength = -1
+		r.Header.Set("Content-Type", mw.FormDataContentType())
+	}
+}
+
 // withAuth sets the Authorization header to the given API key
 func withAuth(apiKey string) requestOption {
 	return func(r *http.Request) {
@@ -34,9 +83,37 @@ func withAuth(apiKey string) requestOption {
 	}
 }
 
-// DoRequest performs an HTTP request with the given options
+// withQuery adds the given values to the request query string
+func withQuery(values url.Values) requestOption {
+	return func(r *http.Request) {
+		if len(values) == 0 {
+			return
+		}
+		q := r.URL.Query()
+		for k, vs := range values {
+			for _, v := range vs {
+				q.Add(k, v)
+			}
+		}
+		r.URL.RawQuery = q.Encode()
+	}
+}
+
+// withHeader sets the request header key to value
+func withHeader(key, value string) requestOption {
+	return func(r *http.Request) {
+		r.Header.Set(key, value)
+	}
+}
+
+// DoRequest performs an HTTP request with the given options, retried as set by Client.Retry
 func (c *Client) DoRequest(method, endpoint string, opts ...requestOption) (*http.Response, error) {
-	req, err := http.NewRequestWithContext(c.Context, method, endpoint, nil)
+	return c.DoRequestWithContext(c.Context, method, endpoint, opts...)
+}
+
+// DoRequestWithContext performs an HTTP request bound to ctx instead of the client context
+func (c *Client) DoRequestWithContext(ctx context.Context, method, endpoint string, opts ...requestOption) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -45,8 +122,7 @@ func (c *Client) DoRequest(method, endpoint string, opts ...requestOption) (*htt
 		opt(req)
 	}
 
-	fmt.Println(req.Body)
-	return c.HTTPClient.Do(req)
+	return c.doWithRetry(req)
 }
 
 // GetRequest performs a GET request
@@ -84,7 +160,7 @@ func handleStatus(resp *http.Response, okStatuses ...int) error {
 	}
 	b, _ := io.ReadAll(resp.Body)
 	if !ok {
-		return &APIError{StatusCode: resp.StatusCode, Status: resp.Status, Body: string(bytes.TrimSpace(b))}
+		return newAPIError(resp, b)
 	}
 	return nil
 }
@@ -102,7 +178,7 @@ func handleJSONResponse[T any](resp *http.Response, out *T, okStatuses ...int) e
 	}
 	if !ok {
 		b, _ := io.ReadAll(resp.Body)
-		return &APIError{StatusCode: resp.StatusCode, Status: resp.Status, Body: string(bytes.TrimSpace(b))}
+		return newAPIError(resp, b)
 	}
 
 	dec := json.NewDecoder(resp.Body)
@@ -125,7 +201,7 @@ func handleRawResponse(resp *http.Response, okStatuses ...int) ([]byte, error) {
 	}
 	b, _ := io.ReadAll(resp.Body)
 	if !ok {
-		return nil, &APIError{StatusCode: resp.StatusCode, Status: resp.Status, Body: string(bytes.TrimSpace(b))}
+		return nil, newAPIError(resp, b)
 	}
 
 	b = bytes.TrimSpace(b)
@@ -133,3 +209,21 @@ func handleRawResponse(resp *http.Response, okStatuses ...int) ([]byte, error) {
 	b = bytes.TrimPrefix(b, []byte("\""))
 	return b, nil
 }
+
+// handleStreamResponse checks the status and returns the response body for
+// the caller to read and close
+func handleStreamResponse(resp *http.Response, okStatuses ...int) (io.ReadCloser, error) {
+	ok := false
+	for _, s := range okStatuses {
+		if resp.StatusCode == s {
+			ok = true
+			break
+		}
+	}
+	if !ok {
+		defer resp.Body.Close()
+		b, _ := io.ReadAll(resp.Body)
+		return nil, newAPIError(resp, b)
+	}
+	return resp.Body, nil
+}
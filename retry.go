@@ -0,0 +1,147 @@
+package grist
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy sets how failed requests are retried, see Client.Retry.
+// Only idempotent requests (GET, HEAD, OPTIONS, PUT and DELETE) are retried
+// unless RetryNonIdempotent is set, and requests whose body cannot be
+// replayed (e.g. streamed uploads) are never retried.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, values below 2 disable retries
+	MaxAttempts int
+	// InitialBackoff is the base delay before the first retry, doubled on each
+	// retry up to MaxBackoff. A random jitter of up to half the delay is removed.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// RetryableStatuses lists the response statuses worth a retry
+	RetryableStatuses []int
+	// RetryNetworkErrors retries requests failing before a response is received
+	RetryNetworkErrors bool
+	// RetryNonIdempotent also retries POST and PATCH requests, which Grist may
+	// have applied before failing
+	RetryNonIdempotent bool
+}
+
+// DefaultRetryPolicy returns a policy of 4 attempts, retrying network errors
+// and the statuses of a restarting or overloaded instance.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:    4,
+		InitialBackoff: 500 * time.Millisecond,
+		MaxBackoff:     10 * time.Second,
+		RetryableStatuses: []int{
+			http.StatusTooManyRequests,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+		RetryNetworkErrors: true,
+	}
+}
+
+// shouldRetry reports whether the request failing with resp or err is worth another attempt
+func (p *RetryPolicy) shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+	if !p.RetryNonIdempotent && !isIdempotent(req.Method) {
+		return false
+	}
+	if err != nil {
+		return p.RetryNetworkErrors && req.Context().Err() == nil && !errors.Is(err, context.Canceled)
+	}
+	return slices.Contains(p.RetryableStatuses, resp.StatusCode)
+}
+
+// backoff returns the delay before the given retry (1 for the first one). The
+// Retry-After header of resp is honored when it asks for a longer delay.
+func (p *RetryPolicy) backoff(retry int, resp *http.Response) time.Duration {
+	delay := p.InitialBackoff
+	for i := 1; i < retry && (p.MaxBackoff <= 0 || delay < p.MaxBackoff); i++ {
+		delay *= 2
+	}
+	if p.MaxBackoff > 0 {
+		delay = min(delay, p.MaxBackoff)
+	}
+	if delay > 0 {
+		delay -= rand.N(delay/2 + 1)
+	}
+
+	if resp != nil {
+		if after, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+			delay = max(delay, after)
+		}
+	}
+	return delay
+}
+
+// parseRetryAfter parses a Retry-After header given in seconds or as an HTTP date
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		return max(t.Sub(now), 0), true
+	}
+	return 0, false
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace,
+		http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// doWithRetry sends req, retrying it as set by the client retry policy
+func (c *Client) doWithRetry(req *http.Request) (*http.Response, error) {
+	policy := c.Retry
+	for attempt := 1; ; attempt++ {
+		resp, err := c.HTTPClient.Do(req)
+		if policy == nil || attempt >= policy.MaxAttempts || !policy.shouldRetry(req, resp, err) {
+			return resp, err
+		}
+
+		delay := policy.backoff(attempt, resp)
+		if resp != nil {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+
+		next := req.Clone(req.Context())
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			next.Body = body
+		}
+		req = next
+	}
+}
@@ -0,0 +1,190 @@
+package grist
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func fastRetryPolicy() *RetryPolicy {
+	p := DefaultRetryPolicy()
+	p.InitialBackoff = time.Millisecond
+	p.MaxBackoff = 5 * time.Millisecond
+	return p
+}
+
+func TestClient_Retry(t *testing.T) {
+	t.Run("Retries retryable statuses until success", func(t *testing.T) {
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if calls.Add(1) < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.Write([]byte(`[]`))
+		}))
+		defer server.Close()
+
+		client, _ := NewGristClient(context.Background(), server.URL, "valid-key")
+		client.Retry = fastRetryPolicy()
+		_, err := ListOrgs(client)
+		assert.NoError(t, err)
+		assert.Equal(t, int32(3), calls.Load())
+	})
+	t.Run("Stops after max attempts", func(t *testing.T) {
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			w.WriteHeader(http.StatusTooManyRequests)
+		}))
+		defer server.Close()
+
+		client, _ := NewGristClient(context.Background(), server.URL, "valid-key")
+		client.Retry = fastRetryPolicy()
+		_, err := ListOrgs(client)
+		assert.ErrorIs(t, err, ErrRateLimited)
+		assert.Equal(t, int32(4), calls.Load())
+	})
+	t.Run("Without policy makes a single attempt", func(t *testing.T) {
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		client, _ := NewGristClient(context.Background(), server.URL, "valid-key")
+		_, err := ListOrgs(client)
+		assert.Error(t, err)
+		assert.Equal(t, int32(1), calls.Load())
+	})
+	t.Run("Does not retry other statuses", func(t *testing.T) {
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		client, _ := NewGristClient(context.Background(), server.URL, "valid-key")
+		client.Retry = fastRetryPolicy()
+		_, err := ListOrgs(client)
+		assert.ErrorIs(t, err, ErrNotFound)
+		assert.Equal(t, int32(1), calls.Load())
+	})
+	t.Run("Retries POST only when opted in, replaying the body", func(t *testing.T) {
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			assert.JSONEq(t, `{"name": "Team"}`, string(body))
+			if calls.Add(1) == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.Write([]byte(`7`))
+		}))
+		defer server.Close()
+
+		client, _ := NewGristClient(context.Background(), server.URL, "valid-key")
+		client.Retry = fastRetryPolicy()
+		_, err := CreateWorkspace(client, 1, "Team")
+		assert.Error(t, err)
+		assert.Equal(t, int32(1), calls.Load())
+
+		calls.Store(0)
+		client.Retry.RetryNonIdempotent = true
+		wsID, err := CreateWorkspace(client, 1, "Team")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		assert.Equal(t, int64(7), *wsID)
+		assert.Equal(t, int32(2), calls.Load())
+	})
+	t.Run("Does not retry streamed bodies", func(t *testing.T) {
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			io.ReadAll(r.Body)
+			calls.Add(1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		client, _ := NewGristClient(context.Background(), server.URL, "valid-key")
+		client.Retry = fastRetryPolicy()
+		client.Retry.RetryNonIdempotent = true
+		doc := &Doc{ID: "doc1"}
+		_, err := doc.UploadAttachments(client, FileUpload{FileName: "a.txt", Content: strings.NewReader("a")})
+		assert.Error(t, err)
+		assert.Equal(t, int32(1), calls.Load())
+	})
+	t.Run("Retries network errors", func(t *testing.T) {
+		var calls atomic.Int32
+		client, _ := NewGristClient(context.Background(), "http://grist.test", "valid-key")
+		client.Retry = fastRetryPolicy()
+		client.HTTPClient.Transport = roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			if calls.Add(1) == 1 {
+				return nil, errors.New("connection reset by peer")
+			}
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`[]`)), Header: http.Header{}}, nil
+		})
+
+		_, err := ListOrgs(client)
+		assert.NoError(t, err)
+		assert.Equal(t, int32(2), calls.Load())
+	})
+	t.Run("Stops waiting when the context is done", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Retry-After", "60")
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		client, _ := NewGristClient(ctx, server.URL, "valid-key")
+		client.Retry = fastRetryPolicy()
+
+		start := time.Now()
+		_, err := ListOrgs(client)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Less(t, time.Since(start), 5*time.Second)
+	})
+}
+
+func TestRetryPolicy_backoff(t *testing.T) {
+	p := &RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+
+	for retry, want := range map[int]time.Duration{1: 100 * time.Millisecond, 2: 200 * time.Millisecond, 3: 400 * time.Millisecond, 10: time.Second} {
+		d := p.backoff(retry, nil)
+		assert.LessOrEqual(t, d, want)
+		assert.GreaterOrEqual(t, d, want/2)
+	}
+
+	resp := &http.Response{Header: http.Header{"Retry-After": {"3"}}}
+	assert.Equal(t, 3*time.Second, p.backoff(1, resp))
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	d, ok := parseRetryAfter("5", now)
+	assert.True(t, ok)
+	assert.Equal(t, 5*time.Second, d)
+
+	d, ok = parseRetryAfter("Wed, 01 Jan 2025 12:00:30 GMT", now)
+	assert.True(t, ok)
+	assert.Equal(t, 30*time.Second, d)
+
+	_, ok = parseRetryAfter("soon", now)
+	assert.False(t, ok)
+	_, ok = parseRetryAfter("", now)
+	assert.False(t, ok)
+}
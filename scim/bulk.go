@@ -0,0 +1,84 @@
+package scim
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/quentinchampenois/go-grist-api"
+)
+
+// BulkOperation is a single operation of a bulk request
+type BulkOperation struct {
+	// Method is POST, PUT, PATCH or DELETE
+	Method string `json:"method"`
+	// BulkID identifies created resources so that later operations can refer to them
+	BulkID string `json:"bulkId,omitempty"`
+	// Path is relative to the SCIM root, e.g. "/Users" or "/Groups/42"
+	Path string `json:"path"`
+	Data any    `json:"data,omitempty"`
+}
+
+// BulkRequest is the body of Bulk
+type BulkRequest struct {
+	// FailOnErrors stops processing after this number of errors, 0 processes every operation
+	FailOnErrors int             `json:"failOnErrors,omitempty"`
+	Operations   []BulkOperation `json:"Operations"`
+}
+
+// BulkOperationResult is the result of a single bulk operation
+type BulkOperationResult struct {
+	Method   string `json:"method"`
+	BulkID   string `json:"bulkId,omitempty"`
+	Location string `json:"location,omitempty"`
+	// Status is the HTTP status code of the operation, as a string
+	Status   string          `json:"status"`
+	Response json.RawMessage `json:"response,omitempty"`
+}
+
+// Err returns the error of a failed operation as an *Error, nil if it succeeded
+func (r BulkOperationResult) Err() error {
+	code, err := strconv.Atoi(r.Status)
+	if err != nil {
+		return fmt.Errorf("scim: invalid bulk operation status %q", r.Status)
+	}
+	if code < http.StatusBadRequest {
+		return nil
+	}
+
+	scimErr := &Error{}
+	if err := json.Unmarshal(r.Response, scimErr); err != nil || (scimErr.Detail == "" && scimErr.ScimType == "") {
+		scimErr.Detail = string(bytes.TrimSpace(r.Response))
+	}
+	scimErr.StatusCode = code
+	return scimErr
+}
+
+type BulkResponse struct {
+	Schemas    []string              `json:"schemas"`
+	Operations []BulkOperationResult `json:"Operations"`
+}
+
+// Bulk sends several operations in one request.
+// source: https://datatracker.ietf.org/doc/html/rfc7644#section-3.7
+func Bulk(c *grist.Client, req BulkRequest) (*BulkResponse, error) {
+	if len(req.Operations) == 0 {
+		return nil, fmt.Errorf("Bulk: operations cannot be empty")
+	}
+
+	body := struct {
+		Schemas []string `json:"schemas"`
+		BulkRequest
+	}{
+		Schemas:     []string{SchemaBulkRequest},
+		BulkRequest: req,
+	}
+
+	var resp BulkResponse
+	if err := do(c, http.MethodPost, "/Bulk", nil, body, &resp, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
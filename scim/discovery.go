@@ -0,0 +1,100 @@
+package scim
+
+import (
+	"net/http"
+
+	"github.com/quentinchampenois/go-grist-api"
+)
+
+// Supported reports whether an optional SCIM feature is supported
+type Supported struct {
+	Supported bool `json:"supported"`
+}
+
+// ServiceProviderConfig describes the SCIM features supported by the instance.
+// source: https://datatracker.ietf.org/doc/html/rfc7643#section-5
+type ServiceProviderConfig struct {
+	Schemas          []string  `json:"schemas"`
+	DocumentationURI string    `json:"documentationUri,omitempty"`
+	Patch            Supported `json:"patch"`
+	Bulk             struct {
+		Supported      bool `json:"supported"`
+		MaxOperations  int  `json:"maxOperations"`
+		MaxPayloadSize int  `json:"maxPayloadSize"`
+	} `json:"bulk"`
+	Filter struct {
+		Supported  bool `json:"supported"`
+		MaxResults int  `json:"maxResults"`
+	} `json:"filter"`
+	ChangePassword        Supported `json:"changePassword"`
+	Sort                  Supported `json:"sort"`
+	ETag                  Supported `json:"etag"`
+	AuthenticationSchemes []struct {
+		Type        string `json:"type"`
+		Name        string `json:"name"`
+		Description string `json:"description"`
+		Primary     bool   `json:"primary,omitempty"`
+	} `json:"authenticationSchemes"`
+	Meta *Meta `json:"meta,omitempty"`
+}
+
+// SchemaAttribute describes an attribute of a schema
+type SchemaAttribute struct {
+	Name          string            `json:"name"`
+	Type          string            `json:"type"`
+	MultiValued   bool              `json:"multiValued"`
+	Description   string            `json:"description,omitempty"`
+	Required      bool              `json:"required"`
+	CaseExact     bool              `json:"caseExact,omitempty"`
+	Mutability    string            `json:"mutability,omitempty"`
+	Returned      string            `json:"returned,omitempty"`
+	Uniqueness    string            `json:"uniqueness,omitempty"`
+	SubAttributes []SchemaAttribute `json:"subAttributes,omitempty"`
+}
+
+// Schema describes a resource schema
+type Schema struct {
+	ID          string            `json:"id"`
+	Name        string            `json:"name,omitempty"`
+	Description string            `json:"description,omitempty"`
+	Attributes  []SchemaAttribute `json:"attributes"`
+	Meta        *Meta             `json:"meta,omitempty"`
+}
+
+// ResourceType describes a resource endpoint
+type ResourceType struct {
+	Schemas     []string `json:"schemas,omitempty"`
+	ID          string   `json:"id,omitempty"`
+	Name        string   `json:"name"`
+	Endpoint    string   `json:"endpoint"`
+	Description string   `json:"description,omitempty"`
+	Schema      string   `json:"schema"`
+	Meta        *Meta    `json:"meta,omitempty"`
+}
+
+// GetServiceProviderConfig returns the SCIM features supported by the instance.
+func GetServiceProviderConfig(c *grist.Client) (*ServiceProviderConfig, error) {
+	var config ServiceProviderConfig
+	if err := do(c, http.MethodGet, "/ServiceProviderConfig", nil, nil, &config, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+// ListSchemas returns the schemas supported by the instance.
+func ListSchemas(c *grist.Client) (*ListResponse[Schema], error) {
+	var list ListResponse[Schema]
+	if err := do(c, http.MethodGet, "/Schemas", nil, nil, &list, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+// ListResourceTypes returns the resource types supported by the instance.
+func ListResourceTypes(c *grist.Client) (*ListResponse[ResourceType], error) {
+	var list ListResponse[ResourceType]
+	if err := do(c, http.MethodGet, "/ResourceTypes", nil, nil, &list, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
@@ -0,0 +1,80 @@
+package scim
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+)
+
+// Helpers building SCIM filter expressions, values are quoted and escaped.
+// source: https://datatracker.ietf.org/doc/html/rfc7644#section-3.4.2.2
+
+func compare(attr, op, value string) string {
+	return attr + " " + op + " " + quote(value)
+}
+
+// quote encodes value as a JSON string, the string syntax of SCIM filters
+func quote(value string) string {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	// Encoding a string cannot fail
+	_ = enc.Encode(value)
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// Eq matches attributes equal to value
+func Eq(attr, value string) string {
+	return compare(attr, "eq", value)
+}
+
+// Ne matches attributes not equal to value
+func Ne(attr, value string) string {
+	return compare(attr, "ne", value)
+}
+
+// Co matches attributes containing value
+func Co(attr, value string) string {
+	return compare(attr, "co", value)
+}
+
+// Sw matches attributes starting with value
+func Sw(attr, value string) string {
+	return compare(attr, "sw", value)
+}
+
+// Ew matches attributes ending with value
+func Ew(attr, value string) string {
+	return compare(attr, "ew", value)
+}
+
+// Pr matches resources having a value for attr
+func Pr(attr string) string {
+	return attr + " pr"
+}
+
+// And combines filters, every filter must match
+func And(filters ...string) string {
+	return join("and", filters)
+}
+
+// Or combines filters, at least one filter must match
+func Or(filters ...string) string {
+	return join("or", filters)
+}
+
+// Not negates a filter
+func Not(filter string) string {
+	return "not (" + filter + ")"
+}
+
+func join(op string, filters []string) string {
+	if len(filters) == 1 {
+		return filters[0]
+	}
+	parts := make([]string, 0, len(filters))
+	for _, f := range filters {
+		parts = append(parts, "("+f+")")
+	}
+	return strings.Join(parts, " "+op+" ")
+}
@@ -0,0 +1,111 @@
+package scim
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/quentinchampenois/go-grist-api"
+)
+
+// Member is a member of a group, Value is the member ID
+type Member struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+	Ref     string `json:"$ref,omitempty"`
+	// Type is "User" or "Group"
+	Type string `json:"type,omitempty"`
+}
+
+// Group is a SCIM group.
+// source: https://datatracker.ietf.org/doc/html/rfc7643#section-4.2
+type Group struct {
+	Schemas     []string `json:"schemas,omitempty"`
+	ID          string   `json:"id,omitempty"`
+	DisplayName string   `json:"displayName"`
+	Members     []Member `json:"members,omitempty"`
+	Meta        *Meta    `json:"meta,omitempty"`
+}
+
+func pathGroup(id string) string {
+	return "/Groups/" + id
+}
+
+func withGroupSchema(g Group) Group {
+	if len(g.Schemas) == 0 {
+		g.Schemas = []string{SchemaGroup}
+	}
+	return g
+}
+
+// CreateGroup creates a group and returns it with its ID.
+func CreateGroup(c *grist.Client, group Group) (*Group, error) {
+	if group.DisplayName == "" {
+		return nil, fmt.Errorf("CreateGroup: displayName cannot be empty")
+	}
+
+	var created Group
+	if err := do(c, http.MethodPost, "/Groups", nil, withGroupSchema(group), &created, http.StatusCreated); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// GetGroup fetches a group by ID.
+func GetGroup(c *grist.Client, id string) (*Group, error) {
+	if id == "" {
+		return nil, fmt.Errorf("GetGroup: id cannot be empty")
+	}
+
+	var group Group
+	if err := do(c, http.MethodGet, pathGroup(id), nil, nil, &group, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &group, nil
+}
+
+// ReplaceGroup replaces every attribute of a group, members included.
+func ReplaceGroup(c *grist.Client, id string, group Group) (*Group, error) {
+	if id == "" {
+		return nil, fmt.Errorf("ReplaceGroup: id cannot be empty")
+	}
+
+	var replaced Group
+	if err := do(c, http.MethodPut, pathGroup(id), nil, withGroupSchema(group), &replaced, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &replaced, nil
+}
+
+// PatchGroup applies PatchOp operations to a group, e.g. to add members.
+func PatchGroup(c *grist.Client, id string, ops ...PatchOperation) (*Group, error) {
+	if id == "" {
+		return nil, fmt.Errorf("PatchGroup: id cannot be empty")
+	}
+	if len(ops) == 0 {
+		return nil, fmt.Errorf("PatchGroup: operations cannot be empty")
+	}
+
+	var patched Group
+	body := patchOp{Schemas: []string{SchemaPatchOp}, Operations: ops}
+	if err := do(c, http.MethodPatch, pathGroup(id), nil, body, &patched, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &patched, nil
+}
+
+// DeleteGroup removes a group.
+func DeleteGroup(c *grist.Client, id string) error {
+	if id == "" {
+		return fmt.Errorf("DeleteGroup: id cannot be empty")
+	}
+	return do(c, http.MethodDelete, pathGroup(id), nil, nil, nil, http.StatusNoContent)
+}
+
+// ListGroups searches groups, opts may be nil.
+func ListGroups(c *grist.Client, opts *ListOptions) (*ListResponse[Group], error) {
+	var list ListResponse[Group]
+	if err := do(c, http.MethodGet, "/Groups", opts.values(), nil, &list, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
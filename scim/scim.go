@@ -0,0 +1,186 @@
+// Package scim is a SCIM v2 client for the users and groups of a Grist instance.
+//
+// Functions take a *grist.Client, like the functions of the grist package:
+//
+//	users, err := scim.ListUsers(gc, &scim.ListOptions{Filter: scim.Eq("userName", "jane@example.com")})
+//
+// SCIM must be enabled on the instance (GRIST_ENABLE_SCIM) and the API key must
+// belong to an install admin.
+// source: https://support.getgrist.com/install/scim/
+package scim
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/quentinchampenois/go-grist-api"
+)
+
+const (
+	scimPath = "/scim/v2"
+
+	// ContentType is the media type of SCIM requests and responses
+	ContentType = "application/scim+json"
+
+	SchemaUser         = "urn:ietf:params:scim:schemas:core:2.0:User"
+	SchemaGroup        = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	SchemaListResponse = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	SchemaPatchOp      = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	SchemaBulkRequest  = "urn:ietf:params:scim:api:messages:2.0:BulkRequest"
+	SchemaBulkResponse = "urn:ietf:params:scim:api:messages:2.0:BulkResponse"
+	SchemaError        = "urn:ietf:params:scim:api:messages:2.0:Error"
+)
+
+// Error is a SCIM error response.
+// source: https://datatracker.ietf.org/doc/html/rfc7644#section-3.12
+type Error struct {
+	Schemas []string `json:"schemas,omitempty"`
+	// Status is the HTTP status code, as a string
+	Status string `json:"status"`
+	// ScimType is the SCIM detail error keyword, e.g. "invalidFilter" or "uniqueness"
+	ScimType string `json:"scimType,omitempty"`
+	Detail   string `json:"detail,omitempty"`
+
+	StatusCode int `json:"-"`
+}
+
+func (e *Error) Error() string {
+	msg := fmt.Sprintf("scim error: %d", e.StatusCode)
+	if e.ScimType != "" {
+		msg += " (" + e.ScimType + ")"
+	}
+	if e.Detail != "" {
+		msg += " - " + e.Detail
+	}
+	return msg
+}
+
+// Is matches the sentinel errors of the grist package, e.g. grist.ErrNotFound
+func (e *Error) Is(target error) bool {
+	sentinel := grist.ErrorForStatus(e.StatusCode)
+	return sentinel != nil && sentinel == target
+}
+
+// Meta holds the resource metadata set by the server
+type Meta struct {
+	ResourceType string `json:"resourceType,omitempty"`
+	Location     string `json:"location,omitempty"`
+	Created      string `json:"created,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+}
+
+// ListResponse is a page of resources
+type ListResponse[T any] struct {
+	Schemas      []string `json:"schemas"`
+	TotalResults int      `json:"totalResults"`
+	StartIndex   int      `json:"startIndex"`
+	ItemsPerPage int      `json:"itemsPerPage"`
+	Resources    []T      `json:"Resources"`
+}
+
+// ListOptions holds the search parameters of list calls, a nil ListOptions lists the first page
+type ListOptions struct {
+	// Filter is a SCIM filter expression, see Eq, And, Or...
+	Filter string
+	// StartIndex is the 1-based index of the first result
+	StartIndex int
+	// Count is the maximum number of results per page
+	Count int
+}
+
+func (o *ListOptions) values() url.Values {
+	values := url.Values{}
+	if o == nil {
+		return values
+	}
+	if o.Filter != "" {
+		values.Set("filter", o.Filter)
+	}
+	if o.StartIndex > 0 {
+		values.Set("startIndex", strconv.Itoa(o.StartIndex))
+	}
+	if o.Count > 0 {
+		values.Set("count", strconv.Itoa(o.Count))
+	}
+	return values
+}
+
+// PatchOperation is a single operation of a PatchOp request
+type PatchOperation struct {
+	// Op is "add", "remove" or "replace"
+	Op    string `json:"op"`
+	Path  string `json:"path,omitempty"`
+	Value any    `json:"value,omitempty"`
+}
+
+type patchOp struct {
+	Schemas    []string         `json:"schemas"`
+	Operations []PatchOperation `json:"Operations"`
+}
+
+// do sends a SCIM request with an optional JSON body and decodes the response into out, if not nil
+func do(c *grist.Client, method, path string, query url.Values, body any, out any, okStatuses ...int) error {
+	endpoint := c.ApiEndpoint() + scimPath + path
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	var payload []byte
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		payload = b
+	}
+
+	resp, err := c.DoRequest(method, endpoint, func(r *http.Request) {
+		r.Header.Set("Authorization", "Bearer "+c.ApiKey)
+		r.Header.Set("Accept", ContentType)
+		if payload != nil {
+			r.Body = io.NopCloser(bytes.NewReader(payload))
+			r.GetBody = func() (io.ReadCloser, error) {
+				return io.NopCloser(bytes.NewReader(payload)), nil
+			}
+			r.ContentLength = int64(len(payload))
+			r.Header.Set("Content-Type", ContentType)
+		}
+	})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	ok := false
+	for _, s := range okStatuses {
+		if resp.StatusCode == s {
+			ok = true
+			break
+		}
+	}
+	if !ok {
+		scimErr := &Error{StatusCode: resp.StatusCode}
+		if err := json.Unmarshal(b, scimErr); err != nil || (scimErr.Detail == "" && scimErr.ScimType == "") {
+			scimErr.Detail = string(bytes.TrimSpace(b))
+		}
+		return scimErr
+	}
+
+	if out == nil || len(bytes.TrimSpace(b)) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(b, out); err != nil {
+		return fmt.Errorf("scim: decode json: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,348 @@
+package scim
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/quentinchampenois/go-grist-api"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *grist.Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	c, err := grist.NewGristClient(context.Background(), server.URL, "valid-key")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	return c
+}
+
+func TestCreateUser(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/api/scim/v2/Users", r.URL.Path)
+		assert.Equal(t, ContentType, r.Header.Get("Content-Type"))
+		assert.Equal(t, "Bearer valid-key", r.Header.Get("Authorization"))
+
+		body, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{"schemas":["urn:ietf:params:scim:schemas:core:2.0:User"],"userName":"jane@example.com","emails":[{"value":"jane@example.com","primary":true}]}`, string(body))
+
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"schemas":["urn:ietf:params:scim:schemas:core:2.0:User"],"id":"5","userName":"jane@example.com","meta":{"resourceType":"User","location":"/api/scim/v2/Users/5"}}`))
+	})
+
+	user, err := CreateUser(c, User{
+		UserName: "jane@example.com",
+		Emails:   []MultiValued{{Value: "jane@example.com", Primary: true}},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	assert.Equal(t, "5", user.ID)
+	assert.Equal(t, "User", user.Meta.ResourceType)
+}
+
+func TestListUsers(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		assert.Equal(t, `(userName sw "jane") and (displayName pr)`, q.Get("filter"))
+		assert.Equal(t, "11", q.Get("startIndex"))
+		assert.Equal(t, "10", q.Get("count"))
+		w.Write([]byte(`{"schemas":["urn:ietf:params:scim:api:messages:2.0:ListResponse"],"totalResults":12,"startIndex":11,"itemsPerPage":10,"Resources":[{"id":"5","userName":"jane@example.com"}]}`))
+	})
+
+	list, err := ListUsers(c, &ListOptions{
+		Filter:     And(Sw("userName", "jane"), Pr("displayName")),
+		StartIndex: 11,
+		Count:      10,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	assert.Equal(t, 12, list.TotalResults)
+	assert.Equal(t, "jane@example.com", list.Resources[0].UserName)
+}
+
+func TestError(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"schemas":["urn:ietf:params:scim:api:messages:2.0:Error"],"status":"400","scimType":"invalidFilter","detail":"bad filter"}`))
+	})
+
+	_, err := ListUsers(c, &ListOptions{Filter: "userName eq"})
+
+	var scimErr *Error
+	if !errors.As(err, &scimErr) {
+		t.Fatalf("Expected *Error, got %v", err)
+	}
+	assert.Equal(t, http.StatusBadRequest, scimErr.StatusCode)
+	assert.Equal(t, "invalidFilter", scimErr.ScimType)
+	assert.Equal(t, "scim error: 400 (invalidFilter) - bad filter", err.Error())
+	assert.ErrorIs(t, err, grist.ErrBadRequest)
+}
+
+func TestFilter(t *testing.T) {
+	assert.Equal(t, `userName eq "jane@example.com"`, Eq("userName", "jane@example.com"))
+	assert.Equal(t, `displayName co "Jane \"JJ\" O\\Brien"`, Co("displayName", `Jane "JJ" O\Brien`))
+	assert.Equal(t, `displayName sw "Élodie <&>"`, Sw("displayName", "Élodie <&>"))
+	assert.Equal(t, `name ew "a\u0000b\u0007"`, Ew("name", "a\x00b\a"))
+	assert.Equal(t, `(userName sw "j") and (displayName pr)`, And(Sw("userName", "j"), Pr("displayName")))
+	assert.Equal(t, `not (active eq "false")`, Not(Eq("active", "false")))
+}
+
+func TestGroups(t *testing.T) {
+	t.Run("CreateGroup posts the group with its schema", func(t *testing.T) {
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "/api/scim/v2/Groups", r.URL.Path)
+			body, _ := io.ReadAll(r.Body)
+			assert.JSONEq(t, `{"schemas":["urn:ietf:params:scim:schemas:core:2.0:Group"],"displayName":"Editors","members":[{"value":"5","type":"User"}]}`, string(body))
+
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"schemas":["urn:ietf:params:scim:schemas:core:2.0:Group"],"id":"7","displayName":"Editors","members":[{"value":"5","display":"Jane","type":"User"}],"meta":{"resourceType":"Group"}}`))
+		})
+
+		group, err := CreateGroup(c, Group{DisplayName: "Editors", Members: []Member{{Value: "5", Type: "User"}}})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		assert.Equal(t, "7", group.ID)
+		assert.Equal(t, "Jane", group.Members[0].Display)
+		assert.Equal(t, "Group", group.Meta.ResourceType)
+	})
+	t.Run("GetGroup fetches the group", func(t *testing.T) {
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodGet, r.Method)
+			assert.Equal(t, "/api/scim/v2/Groups/7", r.URL.Path)
+			w.Write([]byte(`{"id":"7","displayName":"Editors"}`))
+		})
+
+		group, err := GetGroup(c, "7")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		assert.Equal(t, "Editors", group.DisplayName)
+	})
+	t.Run("ReplaceGroup puts the whole group", func(t *testing.T) {
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPut, r.Method)
+			assert.Equal(t, "/api/scim/v2/Groups/7", r.URL.Path)
+			body, _ := io.ReadAll(r.Body)
+			assert.JSONEq(t, `{"schemas":["urn:ietf:params:scim:schemas:core:2.0:Group"],"displayName":"Owners"}`, string(body))
+			w.Write([]byte(`{"id":"7","displayName":"Owners"}`))
+		})
+
+		group, err := ReplaceGroup(c, "7", Group{DisplayName: "Owners"})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		assert.Equal(t, "Owners", group.DisplayName)
+	})
+	t.Run("PatchGroup sends a PatchOp", func(t *testing.T) {
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPatch, r.Method)
+			assert.Equal(t, "/api/scim/v2/Groups/7", r.URL.Path)
+			body, _ := io.ReadAll(r.Body)
+			assert.JSONEq(t, `{"schemas":["urn:ietf:params:scim:api:messages:2.0:PatchOp"],"Operations":[{"op":"add","path":"members","value":[{"value":"6"}]}]}`, string(body))
+			w.Write([]byte(`{"id":"7","displayName":"Editors","members":[{"value":"5"},{"value":"6"}]}`))
+		})
+
+		group, err := PatchGroup(c, "7", PatchOperation{Op: "add", Path: "members", Value: []Member{{Value: "6"}}})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		assert.Len(t, group.Members, 2)
+
+		_, err = PatchGroup(c, "7")
+		assert.EqualError(t, err, "PatchGroup: operations cannot be empty")
+	})
+	t.Run("DeleteGroup expects no content", func(t *testing.T) {
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodDelete, r.Method)
+			assert.Equal(t, "/api/scim/v2/Groups/7", r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		})
+
+		assert.NoError(t, DeleteGroup(c, "7"))
+	})
+	t.Run("ListGroups sends the filter", func(t *testing.T) {
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/api/scim/v2/Groups", r.URL.Path)
+			assert.Equal(t, `displayName eq "Editors"`, r.URL.Query().Get("filter"))
+			w.Write([]byte(`{"schemas":["urn:ietf:params:scim:api:messages:2.0:ListResponse"],"totalResults":1,"Resources":[{"id":"7","displayName":"Editors"}]}`))
+		})
+
+		list, err := ListGroups(c, &ListOptions{Filter: Eq("displayName", "Editors")})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		assert.Equal(t, 1, list.TotalResults)
+		assert.Equal(t, "7", list.Resources[0].ID)
+	})
+	t.Run("With existing name returns the SCIM error", func(t *testing.T) {
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"schemas":["urn:ietf:params:scim:api:messages:2.0:Error"],"status":"409","scimType":"uniqueness","detail":"group already exists"}`))
+		})
+
+		_, err := CreateGroup(c, Group{DisplayName: "Editors"})
+
+		var scimErr *Error
+		if !errors.As(err, &scimErr) {
+			t.Fatalf("Expected *Error, got %v", err)
+		}
+		assert.Equal(t, "uniqueness", scimErr.ScimType)
+		assert.Equal(t, "group already exists", scimErr.Detail)
+		assert.ErrorIs(t, err, grist.ErrConflict)
+	})
+	t.Run("With unknown group returns ErrNotFound", func(t *testing.T) {
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"schemas":["urn:ietf:params:scim:api:messages:2.0:Error"],"status":"404","detail":"Group with id 7 not found"}`))
+		})
+
+		_, err := GetGroup(c, "7")
+		assert.ErrorIs(t, err, grist.ErrNotFound)
+		assert.Equal(t, "scim error: 404 - Group with id 7 not found", err.Error())
+	})
+}
+
+func TestBulk(t *testing.T) {
+	t.Run("Returns the status of every operation", func(t *testing.T) {
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "/api/scim/v2/Bulk", r.URL.Path)
+			body, _ := io.ReadAll(r.Body)
+			assert.JSONEq(t, `{
+				"schemas":["urn:ietf:params:scim:api:messages:2.0:BulkRequest"],
+				"Operations":[
+					{"method":"POST","bulkId":"u1","path":"/Users","data":{"userName":"jane@example.com"}},
+					{"method":"DELETE","path":"/Users/42"}
+				]
+			}`, string(body))
+			w.Write([]byte(`{"schemas":["urn:ietf:params:scim:api:messages:2.0:BulkResponse"],"Operations":[
+				{"method":"POST","bulkId":"u1","location":"/api/scim/v2/Users/5","status":"201"},
+				{"method":"DELETE","status":"404","response":{"schemas":["urn:ietf:params:scim:api:messages:2.0:Error"],"status":"404","detail":"User with id 42 not found"}}
+			]}`))
+		})
+
+		resp, err := Bulk(c, BulkRequest{Operations: []BulkOperation{
+			{Method: http.MethodPost, BulkID: "u1", Path: "/Users", Data: map[string]string{"userName": "jane@example.com"}},
+			{Method: http.MethodDelete, Path: "/Users/42"},
+		}})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		assert.Len(t, resp.Operations, 2)
+
+		created := resp.Operations[0]
+		assert.Equal(t, "201", created.Status)
+		assert.Equal(t, "/api/scim/v2/Users/5", created.Location)
+		assert.NoError(t, created.Err())
+
+		err = resp.Operations[1].Err()
+		var scimErr *Error
+		if !errors.As(err, &scimErr) {
+			t.Fatalf("Expected *Error, got %v", err)
+		}
+		assert.Equal(t, http.StatusNotFound, scimErr.StatusCode)
+		assert.Equal(t, "User with id 42 not found", scimErr.Detail)
+		assert.ErrorIs(t, err, grist.ErrNotFound)
+	})
+	t.Run("With too many operations returns the SCIM error", func(t *testing.T) {
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusRequestEntityTooLarge)
+			w.Write([]byte(`{"schemas":["urn:ietf:params:scim:api:messages:2.0:Error"],"status":"413","detail":"too many operations"}`))
+		})
+
+		_, err := Bulk(c, BulkRequest{Operations: []BulkOperation{{Method: http.MethodDelete, Path: "/Users/42"}}})
+
+		var scimErr *Error
+		if !errors.As(err, &scimErr) {
+			t.Fatalf("Expected *Error, got %v", err)
+		}
+		assert.Equal(t, http.StatusRequestEntityTooLarge, scimErr.StatusCode)
+		assert.Equal(t, "too many operations", scimErr.Detail)
+	})
+	t.Run("Without operations returns error", func(t *testing.T) {
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		})
+
+		_, err := Bulk(c, BulkRequest{})
+		assert.EqualError(t, err, "Bulk: operations cannot be empty")
+	})
+}
+
+func TestDiscovery(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		switch r.URL.Path {
+		case "/api/scim/v2/ServiceProviderConfig":
+			w.Write([]byte(`{
+				"schemas":["urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"],
+				"patch":{"supported":true},
+				"bulk":{"supported":true,"maxOperations":1000,"maxPayloadSize":1048576},
+				"filter":{"supported":true,"maxResults":200},
+				"changePassword":{"supported":false},
+				"sort":{"supported":false},
+				"etag":{"supported":false},
+				"authenticationSchemes":[{"type":"oauthbearertoken","name":"OAuth Bearer Token","description":"Authentication scheme using the Grist API key"}]
+			}`))
+		case "/api/scim/v2/ResourceTypes":
+			w.Write([]byte(`{"totalResults":2,"Resources":[
+				{"id":"User","name":"User","endpoint":"/Users","schema":"urn:ietf:params:scim:schemas:core:2.0:User"},
+				{"id":"Group","name":"Group","endpoint":"/Groups","schema":"urn:ietf:params:scim:schemas:core:2.0:Group"}
+			]}`))
+		case "/api/scim/v2/Schemas":
+			w.Write([]byte(`{"totalResults":1,"Resources":[
+				{"id":"urn:ietf:params:scim:schemas:core:2.0:Group","name":"Group","attributes":[
+					{"name":"members","type":"complex","multiValued":true,"required":false,"subAttributes":[
+						{"name":"value","type":"string","multiValued":false,"required":false,"mutability":"immutable"}
+					]}
+				]}
+			]}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	t.Run("GetServiceProviderConfig", func(t *testing.T) {
+		config, err := GetServiceProviderConfig(c)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		assert.True(t, config.Patch.Supported)
+		assert.True(t, config.Bulk.Supported)
+		assert.Equal(t, 1000, config.Bulk.MaxOperations)
+		assert.Equal(t, 200, config.Filter.MaxResults)
+		assert.False(t, config.Sort.Supported)
+		assert.Equal(t, "oauthbearertoken", config.AuthenticationSchemes[0].Type)
+	})
+	t.Run("ListResourceTypes", func(t *testing.T) {
+		list, err := ListResourceTypes(c)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		assert.Equal(t, 2, list.TotalResults)
+		assert.Equal(t, "/Groups", list.Resources[1].Endpoint)
+		assert.Equal(t, SchemaGroup, list.Resources[1].Schema)
+	})
+	t.Run("ListSchemas", func(t *testing.T) {
+		list, err := ListSchemas(c)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		members := list.Resources[0].Attributes[0]
+		assert.Equal(t, SchemaGroup, list.Resources[0].ID)
+		assert.True(t, members.MultiValued)
+		assert.Equal(t, "immutable", members.SubAttributes[0].Mutability)
+	})
+}
@@ -0,0 +1,130 @@
+package scim
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/quentinchampenois/go-grist-api"
+)
+
+// Name is the name of a user
+type Name struct {
+	Formatted  string `json:"formatted,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+	GivenName  string `json:"givenName,omitempty"`
+}
+
+// MultiValued is an entry of a multi-valued attribute such as emails or photos
+type MultiValued struct {
+	Value   string `json:"value"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+}
+
+// User is a SCIM user, UserName is the user email in Grist.
+// source: https://datatracker.ietf.org/doc/html/rfc7643#section-4.1
+type User struct {
+	Schemas           []string      `json:"schemas,omitempty"`
+	ID                string        `json:"id,omitempty"`
+	UserName          string        `json:"userName"`
+	Name              *Name         `json:"name,omitempty"`
+	DisplayName       string        `json:"displayName,omitempty"`
+	Emails            []MultiValued `json:"emails,omitempty"`
+	Photos            []MultiValued `json:"photos,omitempty"`
+	PreferredLanguage string        `json:"preferredLanguage,omitempty"`
+	Locale            string        `json:"locale,omitempty"`
+	Meta              *Meta         `json:"meta,omitempty"`
+}
+
+func pathUser(id string) string {
+	return "/Users/" + id
+}
+
+func withUserSchema(u User) User {
+	if len(u.Schemas) == 0 {
+		u.Schemas = []string{SchemaUser}
+	}
+	return u
+}
+
+// CreateUser creates a user and returns it with its ID.
+func CreateUser(c *grist.Client, user User) (*User, error) {
+	if user.UserName == "" {
+		return nil, fmt.Errorf("CreateUser: userName cannot be empty")
+	}
+
+	var created User
+	if err := do(c, http.MethodPost, "/Users", nil, withUserSchema(user), &created, http.StatusCreated); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// GetUser fetches a user by ID.
+func GetUser(c *grist.Client, id string) (*User, error) {
+	if id == "" {
+		return nil, fmt.Errorf("GetUser: id cannot be empty")
+	}
+
+	var user User
+	if err := do(c, http.MethodGet, pathUser(id), nil, nil, &user, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// ReplaceUser replaces every attribute of a user.
+func ReplaceUser(c *grist.Client, id string, user User) (*User, error) {
+	if id == "" {
+		return nil, fmt.Errorf("ReplaceUser: id cannot be empty")
+	}
+
+	var replaced User
+	if err := do(c, http.MethodPut, pathUser(id), nil, withUserSchema(user), &replaced, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &replaced, nil
+}
+
+// PatchUser applies PatchOp operations to a user.
+func PatchUser(c *grist.Client, id string, ops ...PatchOperation) (*User, error) {
+	if id == "" {
+		return nil, fmt.Errorf("PatchUser: id cannot be empty")
+	}
+	if len(ops) == 0 {
+		return nil, fmt.Errorf("PatchUser: operations cannot be empty")
+	}
+
+	var patched User
+	body := patchOp{Schemas: []string{SchemaPatchOp}, Operations: ops}
+	if err := do(c, http.MethodPatch, pathUser(id), nil, body, &patched, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &patched, nil
+}
+
+// DeleteUser removes a user.
+func DeleteUser(c *grist.Client, id string) error {
+	if id == "" {
+		return fmt.Errorf("DeleteUser: id cannot be empty")
+	}
+	return do(c, http.MethodDelete, pathUser(id), nil, nil, nil, http.StatusNoContent)
+}
+
+// ListUsers searches users, opts may be nil.
+func ListUsers(c *grist.Client, opts *ListOptions) (*ListResponse[User], error) {
+	var list ListResponse[User]
+	if err := do(c, http.MethodGet, "/Users", opts.values(), nil, &list, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+// Me returns the user owning the API key.
+func Me(c *grist.Client) (*User, error) {
+	var user User
+	if err := do(c, http.MethodGet, "/Me", nil, nil, &user, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
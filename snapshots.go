@@ -0,0 +1,240 @@
+package grist
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Snapshot is a saved version of a document.
+// source: https://support.getgrist.com/api/#tag/docs/operation/listSnapshots
+type Snapshot struct {
+	SnapshotID   string            `json:"snapshotId"`
+	LastModified time.Time         `json:"lastModified"`
+	DocID        string            `json:"docId"`
+	Metadata     map[string]string `json:"metadata,omitempty"`
+}
+
+type Snapshots struct {
+	Snapshots []Snapshot `json:"snapshots"`
+}
+
+// SnapshotSelection selects snapshots to remove without listing their IDs
+type SnapshotSelection string
+
+const (
+	// SnapshotsUnlisted selects the snapshots no longer listed by Grist
+	SnapshotsUnlisted SnapshotSelection = "unlisted"
+	// SnapshotsPast selects every snapshot except the current version
+	SnapshotsPast SnapshotSelection = "past"
+)
+
+// DocState is a state of the document history: N is its position, H its hash
+type DocState struct {
+	N int    `json:"n"`
+	H string `json:"h"`
+}
+
+type DocStates struct {
+	States []DocState `json:"states"`
+}
+
+// ComparisonSummary tells which side of a comparison has changes
+type ComparisonSummary string
+
+const (
+	ComparisonSame      ComparisonSummary = "same"
+	ComparisonLeft      ComparisonSummary = "left"
+	ComparisonRight     ComparisonSummary = "right"
+	ComparisonBoth      ComparisonSummary = "both"
+	ComparisonUnrelated ComparisonSummary = "unrelated"
+)
+
+// DocStateComparison is the result of comparing two documents or two states.
+// source: https://support.getgrist.com/api/#tag/docs/operation/compareDoc
+type DocStateComparison struct {
+	Left  DocState `json:"left"`
+	Right DocState `json:"right"`
+	// Parent is the most recent common state, nil when the documents are unrelated
+	Parent  *DocState         `json:"parent"`
+	Summary ComparisonSummary `json:"summary"`
+	// Details holds the changes on each side when requested
+	Details json.RawMessage `json:"details,omitempty"`
+}
+
+// Diverged reports whether both documents have changes the other lacks
+func (cmp *DocStateComparison) Diverged() bool {
+	return cmp.Summary == ComparisonBoth || cmp.Summary == ComparisonUnrelated
+}
+
+func pathSnapshots(docID string) string {
+	return pathDescribeDocs(docID) + "/snapshots"
+}
+
+func pathStates(docID string) string {
+	return pathDescribeDocs(docID) + "/states"
+}
+
+func pathCompare(docID string) string {
+	return pathDescribeDocs(docID) + "/compare"
+}
+
+// ListSnapshots lists the saved versions of the document, most recent first.
+// source: https://support.getgrist.com/api/#tag/docs/operation/listSnapshots
+func (d *Doc) ListSnapshots(c *Client) (*Snapshots, error) {
+	endpoint := buildURL(c.ApiEndpoint(), pathSnapshots(d.ID))
+	resp, err := c.GetRequest(
+		endpoint,
+		withAuth(c.ApiKey),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshots Snapshots
+	if err := handleJSONResponse(resp, &snapshots, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &snapshots, nil
+}
+
+func (d *Doc) removeSnapshots(c *Client, body any) ([]string, error) {
+	endpoint := buildURL(c.ApiEndpoint(), pathSnapshots(d.ID)+"/remove")
+	jsonBody, err := withJSONBody(body)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.PostRequest(
+		endpoint,
+		withAuth(c.ApiKey),
+		jsonBody,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var removed struct {
+		SnapshotIDs []string `json:"snapshotIds"`
+	}
+	if err := handleJSONResponse(resp, &removed, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return removed.SnapshotIDs, nil
+}
+
+// RemoveSnapshots removes snapshots by ID and returns the removed IDs.
+// source: https://support.getgrist.com/api/#tag/docs/operation/deleteSnapshots
+func (d *Doc) RemoveSnapshots(c *Client, snapshotIDs ...string) ([]string, error) {
+	if len(snapshotIDs) == 0 {
+		return nil, fmt.Errorf("RemoveSnapshots: snapshot ids cannot be empty")
+	}
+	return d.removeSnapshots(c, struct {
+		SnapshotIDs []string `json:"snapshotIds"`
+	}{SnapshotIDs: snapshotIDs})
+}
+
+// RemoveSnapshotsBySelection removes the selected snapshots and returns the removed IDs.
+// source: https://support.getgrist.com/api/#tag/docs/operation/deleteSnapshots
+func (d *Doc) RemoveSnapshotsBySelection(c *Client, selection SnapshotSelection) ([]string, error) {
+	if selection != SnapshotsUnlisted && selection != SnapshotsPast {
+		return nil, fmt.Errorf("RemoveSnapshotsBySelection: invalid selection %q", selection)
+	}
+	return d.removeSnapshots(c, struct {
+		Select SnapshotSelection `json:"select"`
+	}{Select: selection})
+}
+
+// ListStates lists the states of the document history, most recent first.
+// source: https://support.getgrist.com/api/#tag/docs/operation/listStates
+func (d *Doc) ListStates(c *Client) (*DocStates, error) {
+	endpoint := buildURL(c.ApiEndpoint(), pathStates(d.ID))
+	resp, err := c.GetRequest(
+		endpoint,
+		withAuth(c.ApiKey),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var states DocStates
+	if err := handleJSONResponse(resp, &states, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &states, nil
+}
+
+// RemoveStates prunes the document history, keeping the keep most recent states.
+// source: https://support.getgrist.com/api/#tag/docs/operation/deleteActions
+func (d *Doc) RemoveStates(c *Client, keep int) error {
+	if keep < 1 {
+		return fmt.Errorf("RemoveStates: keep must be at least 1, got %d", keep)
+	}
+
+	endpoint := buildURL(c.ApiEndpoint(), pathStates(d.ID)+"/remove")
+	jsonBody, err := withJSONBody(struct {
+		Keep int `json:"keep"`
+	}{Keep: keep})
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.PostRequest(
+		endpoint,
+		withAuth(c.ApiKey),
+		jsonBody,
+	)
+	if err != nil {
+		return err
+	}
+	return handleStatus(resp, http.StatusOK)
+}
+
+// Compare compares the document with another document, details includes the changes of each side.
+// source: https://support.getgrist.com/api/#tag/docs/operation/compareDoc
+func (d *Doc) Compare(c *Client, otherDocID string, details bool) (*DocStateComparison, error) {
+	if otherDocID == "" {
+		return nil, fmt.Errorf("Compare: other doc id cannot be empty")
+	}
+
+	values := url.Values{}
+	if details {
+		values.Set("detail", "true")
+	}
+	return d.compare(c, pathCompare(d.ID)+"/"+otherDocID, values)
+}
+
+// CompareStates compares two states of the document history, identified by their hash.
+// An empty right hash compares with the current state.
+// source: https://support.getgrist.com/api/#tag/docs/operation/compareVersions
+func (d *Doc) CompareStates(c *Client, leftHash, rightHash string) (*DocStateComparison, error) {
+	if leftHash == "" {
+		return nil, fmt.Errorf("CompareStates: left hash cannot be empty")
+	}
+
+	values := url.Values{"left": {leftHash}}
+	if rightHash != "" {
+		values.Set("right", rightHash)
+	}
+	return d.compare(c, pathCompare(d.ID), values)
+}
+
+func (d *Doc) compare(c *Client, path string, values url.Values) (*DocStateComparison, error) {
+	endpoint := buildURL(c.ApiEndpoint(), path)
+	resp, err := c.GetRequest(
+		endpoint,
+		withAuth(c.ApiKey),
+		withQuery(values),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var cmp DocStateComparison
+	if err := handleJSONResponse(resp, &cmp, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &cmp, nil
+}
@@ -0,0 +1,160 @@
+package grist
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDoc_ListSnapshots(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/api/docs/doc1/snapshots", r.URL.Path)
+		w.Write([]byte(`{"snapshots":[{"snapshotId":"s1","lastModified":"2024-05-01T10:00:00Z","docId":"doc1~s1","metadata":{"label":"daily"}}]}`))
+	}))
+	defer server.Close()
+
+	client, _ := NewGristClient(context.Background(), server.URL, "valid-key")
+	doc := &Doc{ID: "doc1"}
+	snapshots, err := doc.ListSnapshots(client)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	assert.Equal(t, []Snapshot{{
+		SnapshotID:   "s1",
+		LastModified: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+		DocID:        "doc1~s1",
+		Metadata:     map[string]string{"label": "daily"},
+	}}, snapshots.Snapshots)
+}
+
+func TestDoc_RemoveSnapshots(t *testing.T) {
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/api/docs/doc1/snapshots/remove", r.URL.Path)
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+		w.Write([]byte(`{"snapshotIds":["s1","s2"]}`))
+	}))
+	defer server.Close()
+
+	client, _ := NewGristClient(context.Background(), server.URL, "valid-key")
+	doc := &Doc{ID: "doc1"}
+
+	t.Run("By ID sends snapshotIds", func(t *testing.T) {
+		removed, err := doc.RemoveSnapshots(client, "s1", "s2")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		assert.JSONEq(t, `{"snapshotIds":["s1","s2"]}`, body)
+		assert.Equal(t, []string{"s1", "s2"}, removed)
+	})
+	t.Run("By selection sends select", func(t *testing.T) {
+		_, err := doc.RemoveSnapshotsBySelection(client, SnapshotsUnlisted)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		assert.JSONEq(t, `{"select":"unlisted"}`, body)
+	})
+	t.Run("With invalid input returns error", func(t *testing.T) {
+		_, err := doc.RemoveSnapshots(client)
+		assert.Error(t, err)
+		_, err = doc.RemoveSnapshotsBySelection(client, "all")
+		assert.Error(t, err)
+	})
+}
+
+func TestDoc_RemoveStates(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/api/docs/doc1/states/remove", r.URL.Path)
+		b, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{"keep":3}`, string(b))
+	}))
+	defer server.Close()
+
+	client, _ := NewGristClient(context.Background(), server.URL, "valid-key")
+	doc := &Doc{ID: "doc1"}
+	assert.NoError(t, doc.RemoveStates(client, 3))
+	assert.Error(t, doc.RemoveStates(client, 0))
+}
+
+func TestDoc_Compare(t *testing.T) {
+	t.Run("With details sends detail=true", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/api/docs/doc1/compare/doc2", r.URL.Path)
+			assert.Equal(t, "true", r.URL.Query().Get("detail"))
+			w.Write([]byte(`{
+				"left": {"n": 5, "h": "aaa"},
+				"right": {"n": 4, "h": "bbb"},
+				"parent": {"n": 3, "h": "ccc"},
+				"summary": "both",
+				"details": {"leftChanges": {}, "rightChanges": {}}
+			}`))
+		}))
+		defer server.Close()
+
+		client, _ := NewGristClient(context.Background(), server.URL, "valid-key")
+		doc := &Doc{ID: "doc1"}
+		cmp, err := doc.Compare(client, "doc2", true)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		assert.Equal(t, DocState{N: 5, H: "aaa"}, cmp.Left)
+		assert.Equal(t, DocState{N: 4, H: "bbb"}, cmp.Right)
+		assert.Equal(t, &DocState{N: 3, H: "ccc"}, cmp.Parent)
+		assert.Equal(t, ComparisonBoth, cmp.Summary)
+		assert.JSONEq(t, `{"leftChanges": {}, "rightChanges": {}}`, string(cmp.Details))
+		assert.True(t, cmp.Diverged())
+	})
+	t.Run("Without details has no detail param and a nil parent", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.False(t, r.URL.Query().Has("detail"))
+			w.Write([]byte(`{"left": {"n": 2, "h": "aaa"}, "right": {"n": 7, "h": "zzz"}, "parent": null, "summary": "unrelated"}`))
+		}))
+		defer server.Close()
+
+		client, _ := NewGristClient(context.Background(), server.URL, "valid-key")
+		doc := &Doc{ID: "doc1"}
+		cmp, err := doc.Compare(client, "doc2", false)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		assert.Nil(t, cmp.Parent)
+		assert.Nil(t, cmp.Details)
+		assert.Equal(t, ComparisonUnrelated, cmp.Summary)
+	})
+}
+
+func TestDoc_CompareStates(t *testing.T) {
+	var query map[string][]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/docs/doc1/compare", r.URL.Path)
+		query = r.URL.Query()
+		w.Write([]byte(`{"left": {"n": 1, "h": "aaa"}, "right": {"n": 2, "h": "bbb"}, "parent": {"n": 1, "h": "aaa"}, "summary": "right"}`))
+	}))
+	defer server.Close()
+
+	client, _ := NewGristClient(context.Background(), server.URL, "valid-key")
+	doc := &Doc{ID: "doc1"}
+
+	cmp, err := doc.CompareStates(client, "aaa", "bbb")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	assert.Equal(t, map[string][]string{"left": {"aaa"}, "right": {"bbb"}}, query)
+	assert.False(t, cmp.Diverged())
+
+	_, err = doc.CompareStates(client, "aaa", "")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]string{"left": {"aaa"}}, query)
+
+	_, err = doc.CompareStates(client, "", "bbb")
+	assert.Error(t, err)
+}
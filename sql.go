@@ -0,0 +1,185 @@
+package grist
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// SQLQuery is a read-only SQL query run against a document.
+// source: https://support.getgrist.com/api/#tag/sql/operation/sqlQuery
+type SQLQuery struct {
+	// SQL is a single SELECT statement, parameters are written as ?
+	SQL string
+	// Args are the values bound to the ? parameters
+	Args []any
+	// Timeout aborts the query on the server after this duration, 0 uses Grist default
+	Timeout time.Duration
+}
+
+// SQLResult holds the rows returned by a SQL query
+type SQLResult struct {
+	Statement string   `json:"statement"`
+	Records   []Record `json:"records"`
+	// Columns lists the column names in the order returned by Grist, it is
+	// empty when the query returns no rows
+	Columns []string `json:"-"`
+}
+
+// UnmarshalJSON decodes the result and keeps the column order of the first row
+func (r *SQLResult) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Statement string `json:"statement"`
+		Records   []struct {
+			Fields json.RawMessage `json:"fields"`
+		} `json:"records"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	result := SQLResult{
+		Statement: raw.Statement,
+		Records:   make([]Record, 0, len(raw.Records)),
+	}
+	for i, rec := range raw.Records {
+		var fields map[string]*CellValue
+		if err := json.Unmarshal(rec.Fields, &fields); err != nil {
+			return fmt.Errorf("row %d: %w", i, err)
+		}
+		result.Records = append(result.Records, Record{Fields: fields})
+	}
+	if len(raw.Records) > 0 {
+		columns, err := objectKeys(raw.Records[0].Fields)
+		if err != nil {
+			return err
+		}
+		result.Columns = columns
+	}
+
+	*r = result
+	return nil
+}
+
+// objectKeys returns the keys of a JSON object in document order
+func objectKeys(data []byte) ([]string, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+
+	var keys []string
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, ok := tok.(string)
+		if !ok {
+			return nil, fmt.Errorf("unexpected json token %v", tok)
+		}
+		keys = append(keys, key)
+
+		var skip json.RawMessage
+		if err := dec.Decode(&skip); err != nil {
+			return nil, err
+		}
+	}
+	return keys, nil
+}
+
+func pathSQL(docID string) string {
+	return pathDescribeDocs(docID) + "/sql"
+}
+
+// Query runs a parameterized SQL query, ctx overrides the client context.
+// source: https://support.getgrist.com/api/#tag/sql/operation/sqlQuery
+func (d *Doc) Query(ctx context.Context, c *Client, sql string, args ...any) (*SQLResult, error) {
+	return d.RunSQL(ctx, c, SQLQuery{SQL: sql, Args: args})
+}
+
+// RunSQL runs a SQL query with its arguments and timeout using POST /sql.
+// source: https://support.getgrist.com/api/#tag/sql/operation/sqlQuery
+func (d *Doc) RunSQL(ctx context.Context, c *Client, q SQLQuery) (*SQLResult, error) {
+	if q.SQL == "" {
+		return nil, fmt.Errorf("RunSQL: sql cannot be empty")
+	}
+
+	body := struct {
+		SQL     string `json:"sql"`
+		Args    []any  `json:"args,omitempty"`
+		Timeout int64  `json:"timeout,omitempty"`
+	}{
+		SQL:     q.SQL,
+		Args:    q.Args,
+		Timeout: q.Timeout.Milliseconds(),
+	}
+
+	endpoint := buildURL(c.ApiEndpoint(), pathSQL(d.ID))
+	jsonBody, err := withJSONBody(body)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.DoRequestWithContext(
+		ctx,
+		http.MethodPost,
+		endpoint,
+		withAuth(c.ApiKey),
+		jsonBody,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var result SQLResult
+	if err := handleJSONResponse(resp, &result, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// QuerySimple runs a SQL query without parameters using GET /sql?q=.
+// source: https://support.getgrist.com/api/#tag/sql/operation/sql
+func (d *Doc) QuerySimple(ctx context.Context, c *Client, sql string) (*SQLResult, error) {
+	if sql == "" {
+		return nil, fmt.Errorf("QuerySimple: sql cannot be empty")
+	}
+
+	endpoint := buildURL(c.ApiEndpoint(), pathSQL(d.ID))
+	resp, err := c.DoRequestWithContext(
+		ctx,
+		http.MethodGet,
+		endpoint,
+		withAuth(c.ApiKey),
+		withQuery(url.Values{"q": {sql}}),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var result SQLResult
+	if err := handleJSONResponse(resp, &result, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ScanInto decodes the rows of a SQL result into T using `grist` struct tags.
+// A selected "id" column is mapped to the field tagged "id".
+func ScanInto[T any](result *SQLResult) ([]T, error) {
+	out := make([]T, len(result.Records))
+	for i, r := range result.Records {
+		if id, ok := r.Fields[rowIDColumn]; ok && id != nil && id.Number != nil {
+			r.ID = int(*id.Number)
+		}
+		if err := UnmarshalRecord(r, &out[i]); err != nil {
+			return nil, fmt.Errorf("row %d: %w", i, err)
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,64 @@
+package grist
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDoc_Query(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/api/docs/doc1/sql", r.URL.Path)
+		body, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{"sql":"select id, Name from Pets where Age > ?","args":[2],"timeout":500}`, string(body))
+		w.Write([]byte(`{"statement":"select id, Name from Pets where Age > ?","records":[
+			{"fields":{"Name":"Rex","id":1}},
+			{"fields":{"Name":"Tom","id":4}}
+		]}`))
+	}))
+	defer server.Close()
+
+	client, _ := NewGristClient(context.Background(), server.URL, "valid-key")
+	doc := &Doc{ID: "doc1"}
+	result, err := doc.RunSQL(context.Background(), client, SQLQuery{
+		SQL:     "select id, Name from Pets where Age > ?",
+		Args:    []any{2},
+		Timeout: 500 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	assert.Equal(t, []string{"Name", "id"}, result.Columns)
+
+	type pet struct {
+		ID   int    `grist:"id"`
+		Name string `grist:"Name"`
+	}
+	pets, err := ScanInto[pet](result)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	assert.Equal(t, []pet{{ID: 1, Name: "Rex"}, {ID: 4, Name: "Tom"}}, pets)
+}
+
+func TestDoc_QuerySimple(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "select * from Pets", r.URL.Query().Get("q"))
+		w.Write([]byte(`{"statement":"select * from Pets","records":[]}`))
+	}))
+	defer server.Close()
+
+	client, _ := NewGristClient(context.Background(), server.URL, "valid-key")
+	doc := &Doc{ID: "doc1"}
+	result, err := doc.QuerySimple(context.Background(), client, "select * from Pets")
+	assert.NoError(t, err)
+	assert.Empty(t, result.Records)
+	assert.Empty(t, result.Columns)
+}
@@ -37,12 +37,39 @@ type Record struct {
 type TableFields struct {
 	TableRef int  `json:"tableRef"`
 	OnDemand bool `json:"onDemand"`
+	// Metadata returned by ListTables
+	TableID                  string `json:"tableId,omitempty"`
+	PrimaryViewID            int    `json:"primaryViewId,omitempty"`
+	SummarySourceTable       int    `json:"summarySourceTable,omitempty"`
+	RawViewSectionRef        int    `json:"rawViewSectionRef,omitempty"`
+	RecordCardViewSectionRef int    `json:"recordCardViewSectionRef,omitempty"`
+}
+
+// TablesUpdate is the body of ModifyTables.
+// source: https://support.getgrist.com/api/#tag/tables/operation/modifyTables
+type TablesUpdate struct {
+	Tables []TableUpdate `json:"tables"`
+}
+
+// TableUpdate holds the metadata to change on the table ID.
+type TableUpdate struct {
+	ID     string            `json:"id"`
+	Fields TableFieldsUpdate `json:"fields"`
+}
+
+// TableFieldsUpdate holds the table metadata that can be modified, unset fields are left unchanged.
+type TableFieldsUpdate struct {
+	// TableID renames the table
+	TableID  string `json:"tableId,omitempty"`
+	OnDemand *bool  `json:"onDemand,omitempty"`
 }
 
 func pathListTables(docID string) string {
 	return pathDescribeDocs(docID) + "/tables"
 }
 
+// ListTables lists the tables of a document.
+// source: https://support.getgrist.com/api/#tag/tables/operation/listTables
 func (d *Doc) ListTables(c *Client) (*Tables, error) {
 	endpoint := buildURL(c.ApiEndpoint(), pathListTables(d.ID))
 	resp, err := c.GetRequest(
@@ -60,12 +87,13 @@ func (d *Doc) ListTables(c *Client) (*Tables, error) {
 	return &t, nil
 }
 
+// CreateTables adds tables with their columns to a document.
+// source: https://support.getgrist.com/api/#tag/tables/operation/addTables
 func (d *Doc) CreateTables(c *Client, obj TablesWithColumns) (*Tables, error) {
 	endpoint := buildURL(c.ApiEndpoint(), pathListTables(d.ID))
 
 	jsonBody, err := withJSONBody(obj)
 	if err != nil {
-		fmt.Println(err)
 		return nil, err
 	}
 
@@ -86,3 +114,69 @@ func (d *Doc) CreateTables(c *Client, obj TablesWithColumns) (*Tables, error) {
 
 	return &t, nil
 }
+
+// ModifyTables updates the metadata of existing tables.
+// source: https://support.getgrist.com/api/#tag/tables/operation/modifyTables
+func (d *Doc) ModifyTables(c *Client, obj TablesUpdate) error {
+	for _, t := range obj.Tables {
+		if t.ID == "" {
+			return fmt.Errorf("ModifyTables: table id cannot be empty")
+		}
+	}
+
+	endpoint := buildURL(c.ApiEndpoint(), pathListTables(d.ID))
+	jsonBody, err := withJSONBody(obj)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.PatchRequest(
+		endpoint,
+		withAuth(c.ApiKey),
+		jsonBody,
+	)
+	if err != nil {
+		return err
+	}
+	return handleStatus(resp, http.StatusOK)
+}
+
+// RenameTable changes the ID of a table.
+func (d *Doc) RenameTable(c *Client, tableID, newTableID string) error {
+	if newTableID == "" {
+		return fmt.Errorf("RenameTable: new table id cannot be empty")
+	}
+
+	return d.ModifyTables(c, TablesUpdate{
+		Tables: []TableUpdate{
+			{ID: tableID, Fields: TableFieldsUpdate{TableID: newTableID}},
+		},
+	})
+}
+
+// DescribeTable fetches a table with its columns, hidden columns included.
+// Grist has no endpoint for a single table, the table is looked up in ListTables.
+func (d *Doc) DescribeTable(c *Client, tableID string) (*Table, error) {
+	tables, err := d.ListTables(c)
+	if err != nil {
+		return nil, err
+	}
+
+	var table *Table
+	for i := range tables.Tables {
+		if tables.Tables[i].ID == tableID {
+			table = &tables.Tables[i]
+			break
+		}
+	}
+	if table == nil {
+		return nil, fmt.Errorf("DescribeTable: table %s: %w", tableID, ErrNotFound)
+	}
+
+	columns, err := d.ListColumns(c, tableID, true)
+	if err != nil {
+		return nil, err
+	}
+	table.Columns = columns.Columns
+	return table, nil
+}
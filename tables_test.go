@@ -0,0 +1,90 @@
+package grist
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDoc_ModifyTables(t *testing.T) {
+	t.Run("Sends only the set fields", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPatch, r.Method)
+			assert.Equal(t, "/api/docs/doc1/tables", r.URL.Path)
+			body, _ := io.ReadAll(r.Body)
+			assert.JSONEq(t, `{"tables": [
+				{"id": "Pets", "fields": {"onDemand": false}},
+				{"id": "Owners", "fields": {"tableId": "People"}}
+			]}`, string(body))
+		}))
+		defer server.Close()
+
+		client, _ := NewGristClient(context.Background(), server.URL, "valid-key")
+		doc := &Doc{ID: "doc1"}
+		onDemand := false
+		err := doc.ModifyTables(client, TablesUpdate{Tables: []TableUpdate{
+			{ID: "Pets", Fields: TableFieldsUpdate{OnDemand: &onDemand}},
+			{ID: "Owners", Fields: TableFieldsUpdate{TableID: "People"}},
+		}})
+		assert.NoError(t, err)
+	})
+	t.Run("Without table id returns error", func(t *testing.T) {
+		client, _ := NewGristClient(context.Background(), "https://getgrist.com", "valid-key")
+		doc := &Doc{ID: "doc1"}
+		err := doc.ModifyTables(client, TablesUpdate{Tables: []TableUpdate{{}}})
+		assert.EqualError(t, err, "ModifyTables: table id cannot be empty")
+	})
+}
+
+func TestDoc_RenameTable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPatch, r.Method)
+		assert.Equal(t, "/api/docs/doc1/tables", r.URL.Path)
+		body, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{"tables": [{"id": "Pets", "fields": {"tableId": "Animals"}}]}`, string(body))
+	}))
+	defer server.Close()
+
+	client, _ := NewGristClient(context.Background(), server.URL, "valid-key")
+	doc := &Doc{ID: "doc1"}
+	assert.NoError(t, doc.RenameTable(client, "Pets", "Animals"))
+	assert.Error(t, doc.RenameTable(client, "Pets", ""))
+}
+
+func TestDoc_DescribeTable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/docs/doc1/tables":
+			w.Write([]byte(`{"tables": [{"id": "Pets", "fields": {"tableRef": 1, "onDemand": false, "primaryViewId": 1}}]}`))
+		case "/api/docs/doc1/tables/Pets/columns":
+			assert.Equal(t, "true", r.URL.Query().Get("hidden"))
+			w.Write([]byte(`{"columns": [{"id": "Name", "fields": {"type": "Text", "label": "Name"}}, {"id": "manualSort", "fields": {"type": "ManualSortPos"}}]}`))
+		default:
+			t.Errorf("Unexpected request %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client, _ := NewGristClient(context.Background(), server.URL, "valid-key")
+	doc := &Doc{ID: "doc1"}
+
+	t.Run("Returns the table with hidden columns", func(t *testing.T) {
+		table, err := doc.DescribeTable(client, "Pets")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		assert.Equal(t, "Pets", table.ID)
+		assert.Equal(t, 1, table.Fields.PrimaryViewID)
+		assert.Len(t, table.Columns, 2)
+		assert.Equal(t, TypeText, table.Columns[0].Fields.Type)
+	})
+	t.Run("With unknown table returns ErrNotFound", func(t *testing.T) {
+		_, err := doc.DescribeTable(client, "Owners")
+		assert.ErrorIs(t, err, ErrNotFound)
+		assert.EqualError(t, err, "DescribeTable: table Owners: not found")
+	})
+}
@@ -0,0 +1,253 @@
+package grist
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// UserAction is a raw Grist user action: its name followed by its arguments,
+// e.g. ["RenameTable", "Pets", "Animals"].
+// source: https://github.com/gristlabs/grist-core/blob/main/documentation/overview.md
+type UserAction []any
+
+// Name returns the action name
+func (a UserAction) Name() string {
+	if len(a) == 0 {
+		return ""
+	}
+	name, _ := a[0].(string)
+	return name
+}
+
+// UserActions builds an ordered batch of user actions applied atomically by
+// Doc.ApplyUserActions. The first invalid action is reported when applying.
+//
+//	actions := grist.NewUserActions().
+//		AddEmptyTable("Pets").
+//		BulkAddRecord("Pets", map[string][]*grist.CellValue{"A": {grist.NewStringCell("Rex")}}).
+//		RenameTable("Pets", "Animals")
+type UserActions struct {
+	actions []UserAction
+	err     error
+}
+
+// NewUserActions returns an empty batch
+func NewUserActions() *UserActions {
+	return &UserActions{}
+}
+
+// Actions returns the actions of the batch
+func (b *UserActions) Actions() []UserAction {
+	return b.actions
+}
+
+// Err returns the first error met while building the batch
+func (b *UserActions) Err() error {
+	return b.err
+}
+
+func (b *UserActions) add(action ...any) *UserActions {
+	b.actions = append(b.actions, UserAction(action))
+	return b
+}
+
+func (b *UserActions) fail(format string, args ...any) *UserActions {
+	if b.err == nil {
+		b.err = fmt.Errorf(format, args...)
+	}
+	return b
+}
+
+// Raw appends any action, for actions without a dedicated method
+func (b *UserActions) Raw(name string, args ...any) *UserActions {
+	if name == "" {
+		return b.fail("raw action: name cannot be empty")
+	}
+	return b.add(append([]any{name}, args...)...)
+}
+
+// AddRecord adds a record, Grist picks the row ID
+func (b *UserActions) AddRecord(tableID string, fields map[string]*CellValue) *UserActions {
+	return b.add("AddRecord", tableID, nil, cellMap(fields))
+}
+
+// BulkAddRecord adds records given column by column, every column must hold the same number of values
+func (b *UserActions) BulkAddRecord(tableID string, columns map[string][]*CellValue) *UserActions {
+	n, ok := columnsLen(columns)
+	if !ok {
+		return b.fail("BulkAddRecord %s: columns have different lengths", tableID)
+	}
+	return b.add("BulkAddRecord", tableID, make([]any, n), cellColumns(columns))
+}
+
+// UpdateRecord updates the fields of a record
+func (b *UserActions) UpdateRecord(tableID string, rowID int, fields map[string]*CellValue) *UserActions {
+	return b.add("UpdateRecord", tableID, rowID, cellMap(fields))
+}
+
+// BulkUpdateRecord updates records given column by column, in the order of rowIDs
+func (b *UserActions) BulkUpdateRecord(tableID string, rowIDs []int, columns map[string][]*CellValue) *UserActions {
+	n, ok := columnsLen(columns)
+	if !ok || (len(columns) > 0 && n != len(rowIDs)) {
+		return b.fail("BulkUpdateRecord %s: columns must hold one value per row id", tableID)
+	}
+	return b.add("BulkUpdateRecord", tableID, rowIDs, cellColumns(columns))
+}
+
+// RemoveRecord removes a record
+func (b *UserActions) RemoveRecord(tableID string, rowID int) *UserActions {
+	return b.add("RemoveRecord", tableID, rowID)
+}
+
+// BulkRemoveRecord removes several records
+func (b *UserActions) BulkRemoveRecord(tableID string, rowIDs []int) *UserActions {
+	return b.add("BulkRemoveRecord", tableID, rowIDs)
+}
+
+// AddTable adds a table with its columns
+func (b *UserActions) AddTable(tableID string, columns []Column) *UserActions {
+	infos := make([]map[string]any, 0, len(columns))
+	for _, col := range columns {
+		info, err := columnInfo(col.Fields)
+		if err != nil {
+			return b.fail("AddTable %s: column %s: %w", tableID, col.ID, err)
+		}
+		info["id"] = col.ID
+		infos = append(infos, info)
+	}
+	return b.add("AddTable", tableID, infos)
+}
+
+// AddEmptyTable adds a table with the default columns A, B and C
+func (b *UserActions) AddEmptyTable(tableID string) *UserActions {
+	return b.add("AddEmptyTable", tableID)
+}
+
+// RenameTable changes the ID of a table
+func (b *UserActions) RenameTable(tableID, newTableID string) *UserActions {
+	return b.add("RenameTable", tableID, newTableID)
+}
+
+// RemoveTable removes a table and its data
+func (b *UserActions) RemoveTable(tableID string) *UserActions {
+	return b.add("RemoveTable", tableID)
+}
+
+// AddColumn adds a column to a table
+func (b *UserActions) AddColumn(tableID, colID string, fields ColumnFields) *UserActions {
+	info, err := columnInfo(fields)
+	if err != nil {
+		return b.fail("AddColumn %s.%s: %w", tableID, colID, err)
+	}
+	return b.add("AddColumn", tableID, colID, info)
+}
+
+// ModifyColumn changes the settings of a column, only the set fields are changed
+func (b *UserActions) ModifyColumn(tableID, colID string, fields ColumnFields) *UserActions {
+	info, err := columnInfo(fields)
+	if err != nil {
+		return b.fail("ModifyColumn %s.%s: %w", tableID, colID, err)
+	}
+	return b.add("ModifyColumn", tableID, colID, info)
+}
+
+// RenameColumn changes the ID of a column
+func (b *UserActions) RenameColumn(tableID, colID, newColID string) *UserActions {
+	return b.add("RenameColumn", tableID, colID, newColID)
+}
+
+// RemoveColumn removes a column
+func (b *UserActions) RemoveColumn(tableID, colID string) *UserActions {
+	return b.add("RemoveColumn", tableID, colID)
+}
+
+// AddView adds a page showing the table, viewType is e.g. "record" or "detail"
+func (b *UserActions) AddView(tableID, viewType, name string) *UserActions {
+	return b.add("AddView", tableID, viewType, name)
+}
+
+// ApplyResult is the result of ApplyUserActions
+type ApplyResult struct {
+	// ActionNum and ActionHash identify the resulting bundle in the document history
+	ActionNum  int    `json:"actionNum"`
+	ActionHash string `json:"actionHash"`
+	// RetValues holds the value returned by each action, e.g. the new row ID of AddRecord
+	RetValues      []json.RawMessage `json:"retValues"`
+	IsModification bool              `json:"isModification"`
+}
+
+// ApplyUserActions applies a batch of user actions atomically, as one undoable bundle.
+// source: https://support.getgrist.com/api/#tag/docs/operation/applyUserActions
+func (d *Doc) ApplyUserActions(c *Client, actions *UserActions) (*ApplyResult, error) {
+	if actions == nil || len(actions.actions) == 0 {
+		return nil, fmt.Errorf("ApplyUserActions: actions cannot be empty")
+	}
+	if actions.err != nil {
+		return nil, fmt.Errorf("ApplyUserActions: %w", actions.err)
+	}
+
+	endpoint := buildURL(c.ApiEndpoint(), pathDescribeDocs(d.ID)+"/apply")
+	jsonBody, err := withJSONBody(actions.actions)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.PostRequest(
+		endpoint,
+		withAuth(c.ApiKey),
+		jsonBody,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var result ApplyResult
+	if err := handleJSONResponse(resp, &result, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// cellMap turns a nil map into an empty one, Grist expects an object
+func cellMap(fields map[string]*CellValue) map[string]*CellValue {
+	if fields == nil {
+		return map[string]*CellValue{}
+	}
+	return fields
+}
+
+func cellColumns(columns map[string][]*CellValue) map[string][]*CellValue {
+	if columns == nil {
+		return map[string][]*CellValue{}
+	}
+	return columns
+}
+
+// columnsLen returns the common length of the columns
+func columnsLen(columns map[string][]*CellValue) (int, bool) {
+	n := -1
+	for _, values := range columns {
+		if n >= 0 && len(values) != n {
+			return 0, false
+		}
+		n = len(values)
+	}
+	if n < 0 {
+		return 0, true
+	}
+	return n, true
+}
+
+// columnInfo converts column fields into the column info object of column actions
+func columnInfo(fields ColumnFields) (map[string]any, error) {
+	b, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+	var info map[string]any
+	if err := json.Unmarshal(b, &info); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
@@ -0,0 +1,84 @@
+package grist
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserActions(t *testing.T) {
+	t.Run("Builds actions in order", func(t *testing.T) {
+		actions := NewUserActions().
+			AddEmptyTable("Pets").
+			BulkAddRecord("Pets", map[string][]*CellValue{"A": {NewStringCell("Rex"), NewStringCell("Tom")}}).
+			ModifyColumn("Pets", "A", ColumnFields{Label: Ptr("Name"), Type: TypeText, Formula: Ptr("")}).
+			RenameTable("Pets", "Animals").
+			Raw("AddRecord", "_grist_ACLRules", nil, map[string]any{"resource": 1})
+
+		if err := actions.Err(); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		b, err := json.Marshal(actions.Actions())
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		assert.JSONEq(t, `[
+			["AddEmptyTable", "Pets"],
+			["BulkAddRecord", "Pets", [null, null], {"A": ["Rex", "Tom"]}],
+			["ModifyColumn", "Pets", "A", {"label": "Name", "type": "Text", "formula": ""}],
+			["RenameTable", "Pets", "Animals"],
+			["AddRecord", "_grist_ACLRules", null, {"resource": 1}]
+		]`, string(b))
+		assert.Equal(t, "RenameTable", actions.Actions()[3].Name())
+	})
+	t.Run("Reports columns of different lengths", func(t *testing.T) {
+		actions := NewUserActions().BulkAddRecord("Pets", map[string][]*CellValue{
+			"A": {NewStringCell("Rex")},
+			"B": {NewNumberCell(1), NewNumberCell(2)},
+		})
+		assert.Error(t, actions.Err())
+	})
+	t.Run("Reports a row count mismatch", func(t *testing.T) {
+		actions := NewUserActions().BulkUpdateRecord("Pets", []int{1, 2}, map[string][]*CellValue{
+			"A": {NewStringCell("Rex")},
+		})
+		assert.Error(t, actions.Err())
+	})
+}
+
+func TestDoc_ApplyUserActions(t *testing.T) {
+	t.Run("Sends the batch and returns the result", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "/api/docs/doc1/apply", r.URL.Path)
+			body, _ := io.ReadAll(r.Body)
+			assert.JSONEq(t, `[["AddRecord", "Pets", null, {"Name": "Rex"}], ["RemoveRecord", "Pets", 3]]`, string(body))
+			w.Write([]byte(`{"actionNum": 12, "actionHash": "abc", "retValues": [4, null], "isModification": true}`))
+		}))
+		defer server.Close()
+
+		client, _ := NewGristClient(context.Background(), server.URL, "valid-key")
+		doc := &Doc{ID: "doc1"}
+		result, err := doc.ApplyUserActions(client, NewUserActions().
+			AddRecord("Pets", map[string]*CellValue{"Name": NewStringCell("Rex")}).
+			RemoveRecord("Pets", 3))
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		assert.Equal(t, 12, result.ActionNum)
+		assert.Equal(t, "abc", result.ActionHash)
+		assert.Len(t, result.RetValues, 2)
+		assert.JSONEq(t, `4`, string(result.RetValues[0]))
+	})
+	t.Run("With invalid batch does not send it", func(t *testing.T) {
+		client, _ := NewGristClient(context.Background(), "http://127.0.0.1:1", "valid-key")
+		doc := &Doc{ID: "doc1"}
+		_, err := doc.ApplyUserActions(client, NewUserActions())
+		assert.Error(t, err)
+	})
+}
@@ -12,4 +12,6 @@ type User struct {
 	Picture string     `json:"picture,omitempty"`
 	Ref     string     `json:"ref,omitempty"`
 	Member  bool       `json:"isMember,omitempty"`
+	// ParentAccess is the role inherited from the parent resource
+	ParentAccess AccessRole `json:"parentAccess,omitempty"`
 }
@@ -0,0 +1,184 @@
+// Package webhook receives Grist webhook calls.
+//
+// Grist POSTs a JSON array of row objects to the webhook URL. The handlers
+// of this package decode the rows into grist.Record values, or into structs
+// with `grist` tags, and pass them to a callback:
+//
+//	h := webhook.NewTypedHandler(func(ctx context.Context, pets []Pet) error {
+//		return store(ctx, pets)
+//	}, &webhook.Options{Secret: os.Getenv("WEBHOOK_SECRET")})
+//	http.Handle("/hooks/pets", h)
+//
+// A callback error answers 500, so the batch stays in the Grist queue and is retried.
+package webhook
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/quentinchampenois/go-grist-api"
+)
+
+const (
+	// DefaultHeader is the header checked for the shared secret
+	DefaultHeader = "Authorization"
+	// DefaultQueryParam is the query parameter checked for the shared secret
+	DefaultQueryParam = "token"
+	// DefaultMaxBodyBytes is the default size limit of a webhook body
+	DefaultMaxBodyBytes = 10 << 20
+)
+
+// Options configures a webhook handler, a nil Options accepts any caller
+type Options struct {
+	// Secret, when set, must be sent in Header (optionally as "Bearer <secret>")
+	// or in QueryParam
+	Secret     string
+	Header     string
+	QueryParam string
+	// MaxBodyBytes limits the size of the body, DefaultMaxBodyBytes when 0
+	MaxBodyBytes int64
+}
+
+func (o *Options) header() string {
+	if o.Header == "" {
+		return DefaultHeader
+	}
+	return o.Header
+}
+
+func (o *Options) queryParam() string {
+	if o.QueryParam == "" {
+		return DefaultQueryParam
+	}
+	return o.QueryParam
+}
+
+func (o *Options) maxBodyBytes() int64 {
+	if o.MaxBodyBytes <= 0 {
+		return DefaultMaxBodyBytes
+	}
+	return o.MaxBodyBytes
+}
+
+// authorized checks the shared secret of the request in constant time
+func (o *Options) authorized(r *http.Request) bool {
+	if o.Secret == "" {
+		return true
+	}
+
+	candidates := []string{r.URL.Query().Get(o.queryParam())}
+	if h := r.Header.Get(o.header()); h != "" {
+		candidates = append(candidates, h, strings.TrimPrefix(h, "Bearer "))
+	}
+	for _, c := range candidates {
+		if c != "" && subtle.ConstantTimeCompare([]byte(c), []byte(o.Secret)) == 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// Handler decodes Grist webhook calls and dispatches them to a callback
+type Handler struct {
+	opts     Options
+	dispatch func(ctx context.Context, records []grist.Record) error
+}
+
+// NewHandler returns a handler passing the received rows as records to fn, opts may be nil
+func NewHandler(fn func(ctx context.Context, records []grist.Record) error, opts *Options) *Handler {
+	h := &Handler{dispatch: fn}
+	if opts != nil {
+		h.opts = *opts
+	}
+	return h
+}
+
+// NewTypedHandler returns a handler decoding the received rows into T with
+// grist.UnmarshalRecord before calling fn, opts may be nil
+func NewTypedHandler[T any](fn func(ctx context.Context, items []T) error, opts *Options) *Handler {
+	return NewHandler(func(ctx context.Context, records []grist.Record) error {
+		items := make([]T, len(records))
+		for i, r := range records {
+			if err := grist.UnmarshalRecord(r, &items[i]); err != nil {
+				return &decodeError{fmt.Errorf("record %d: %w", r.ID, err)}
+			}
+		}
+		return fn(ctx, items)
+	}, opts)
+}
+
+// decodeError marks errors caused by the payload rather than by the callback
+type decodeError struct {
+	err error
+}
+
+func (e *decodeError) Error() string {
+	return "invalid webhook payload: " + e.err.Error()
+}
+
+func (e *decodeError) Unwrap() error {
+	return e.err
+}
+
+// ServeHTTP answers 405 for non POST requests, 401 for a missing or wrong
+// secret, 400 for an invalid payload, 500 when the callback fails and 200 otherwise
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !h.opts.authorized(r) {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	body := http.MaxBytesReader(w, r.Body, h.opts.maxBodyBytes())
+	records, err := DecodeRecords(body)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			http.Error(w, "payload too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.dispatch(r.Context(), records); err != nil {
+		var decodeErr *decodeError
+		if errors.As(err, &decodeErr) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, "webhook callback failed", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// DecodeRecords decodes a Grist webhook body, an array of row objects with an
+// "id" key and one key per column, into records
+func DecodeRecords(r io.Reader) ([]grist.Record, error) {
+	var rows []map[string]*grist.CellValue
+	if err := json.NewDecoder(r).Decode(&rows); err != nil {
+		return nil, &decodeError{err}
+	}
+
+	records := make([]grist.Record, 0, len(rows))
+	for i, row := range rows {
+		id, ok := row["id"]
+		if !ok || id == nil || id.Number == nil {
+			return nil, &decodeError{fmt.Errorf("row %d has no id", i)}
+		}
+		delete(row, "id")
+		records = append(records, grist.Record{ID: int(*id.Number), Fields: row})
+	}
+	return records, nil
+}
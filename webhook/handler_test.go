@@ -0,0 +1,99 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/quentinchampenois/go-grist-api"
+	"github.com/stretchr/testify/assert"
+)
+
+const payload = `[{"id":1,"manualSort":1,"Name":"Rex","Tags":["L","dog"]},{"id":2,"manualSort":2,"Name":"Tom","Tags":null}]`
+
+type pet struct {
+	ID   int      `grist:"id"`
+	Name string   `grist:"Name"`
+	Tags []string `grist:"Tags"`
+}
+
+func TestHandler(t *testing.T) {
+	t.Run("With records dispatches them", func(t *testing.T) {
+		var got []grist.Record
+		h := NewHandler(func(ctx context.Context, records []grist.Record) error {
+			got = records
+			return nil
+		}, nil)
+
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(payload)))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Len(t, got, 2)
+		assert.Equal(t, 1, got[0].ID)
+		assert.Equal(t, "Rex", *got[0].Fields["Name"].String)
+		assert.NotContains(t, got[0].Fields, "id")
+	})
+	t.Run("With typed handler decodes structs", func(t *testing.T) {
+		var got []pet
+		h := NewTypedHandler(func(ctx context.Context, pets []pet) error {
+			got = pets
+			return nil
+		}, nil)
+
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(payload)))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, []pet{{ID: 1, Name: "Rex", Tags: []string{"dog"}}, {ID: 2, Name: "Tom"}}, got)
+	})
+	t.Run("With secret checks header and query token", func(t *testing.T) {
+		h := NewHandler(func(ctx context.Context, records []grist.Record) error {
+			return nil
+		}, &Options{Secret: "s3cret"})
+
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(payload)))
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+		rec = httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(payload))
+		req.Header.Set("Authorization", "Bearer s3cret")
+		h.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		rec = httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/?token=s3cret", strings.NewReader(payload)))
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+	t.Run("With invalid payload answers 400", func(t *testing.T) {
+		h := NewHandler(func(ctx context.Context, records []grist.Record) error {
+			return nil
+		}, nil)
+
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"id":1}`)))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+	t.Run("With failing callback answers 500", func(t *testing.T) {
+		h := NewHandler(func(ctx context.Context, records []grist.Record) error {
+			return errors.New("database down")
+		}, nil)
+
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(payload)))
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+	t.Run("With GET answers 405", func(t *testing.T) {
+		h := NewHandler(func(ctx context.Context, records []grist.Record) error {
+			return nil
+		}, nil)
+
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	})
+}
@@ -0,0 +1,230 @@
+package grist
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// WebhookEventType is a record event triggering a webhook
+type WebhookEventType string
+
+const (
+	WebhookEventAdd    WebhookEventType = "add"
+	WebhookEventUpdate WebhookEventType = "update"
+)
+
+// WebhookFields holds the settings of a webhook. Unset fields are left
+// unchanged by ModifyWebhook.
+// source: https://support.getgrist.com/api/#tag/webhooks
+type WebhookFields struct {
+	Name       string             `json:"name,omitempty"`
+	Memo       string             `json:"memo,omitempty"`
+	URL        string             `json:"url,omitempty"`
+	Enabled    *bool              `json:"enabled,omitempty"`
+	EventTypes []WebhookEventType `json:"eventTypes,omitempty"`
+	// IsReadyColumn is a Bool column, records are sent only once it is true.
+	// Use &ReadyColumn{} to clear it.
+	IsReadyColumn *ReadyColumn `json:"isReadyColumn,omitempty"`
+	TableID       string       `json:"tableId,omitempty"`
+	// UnsubscribeKey is returned by ListWebhooks
+	UnsubscribeKey string `json:"unsubscribeKey,omitempty"`
+}
+
+// ReadyColumn is the isReadyColumn setting of a webhook, an empty ColID
+// is sent as null to clear the setting
+type ReadyColumn struct {
+	ColID string
+}
+
+func (r ReadyColumn) MarshalJSON() ([]byte, error) {
+	if r.ColID == "" {
+		return []byte("null"), nil
+	}
+	return json.Marshal(r.ColID)
+}
+
+func (r *ReadyColumn) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*r = ReadyColumn{}
+		return nil
+	}
+	return json.Unmarshal(data, &r.ColID)
+}
+
+// WebhookBatchStatus describes the last batch of events sent by a webhook
+type WebhookBatchStatus struct {
+	Size         int     `json:"size"`
+	ErrorMessage *string `json:"errorMessage"`
+	HTTPStatus   *int    `json:"httpStatus"`
+	Status       string  `json:"status"`
+	Attempts     int     `json:"attempts"`
+}
+
+// WebhookUsage holds the delivery stats of a webhook
+type WebhookUsage struct {
+	NumWaiting       int                 `json:"numWaiting"`
+	Status           string              `json:"status"`
+	UpdatedTime      *int64              `json:"updatedTime"`
+	LastSuccessTime  *int64              `json:"lastSuccessTime"`
+	LastFailureTime  *int64              `json:"lastFailureTime"`
+	LastErrorMessage *string             `json:"lastErrorMessage"`
+	LastHTTPStatus   *int                `json:"lastHttpStatus"`
+	LastEventBatch   *WebhookBatchStatus `json:"lastEventBatch"`
+}
+
+type Webhook struct {
+	ID     string        `json:"id,omitempty"`
+	Fields WebhookFields `json:"fields"`
+	Usage  *WebhookUsage `json:"usage,omitempty"`
+}
+
+type Webhooks struct {
+	Webhooks []Webhook `json:"webhooks"`
+}
+
+func pathWebhooks(docID string) string {
+	return pathDescribeDocs(docID) + "/webhooks"
+}
+
+func pathWebhook(docID, webhookID string) string {
+	return pathWebhooks(docID) + "/" + webhookID
+}
+
+func pathWebhooksQueue(docID string) string {
+	return pathWebhooks(docID) + "/queue"
+}
+
+// ListWebhooks lists the webhooks of the document with their usage stats.
+// source: https://support.getgrist.com/api/#tag/webhooks/operation/listWebhooks
+func (d *Doc) ListWebhooks(c *Client) (*Webhooks, error) {
+	endpoint := buildURL(c.ApiEndpoint(), pathWebhooks(d.ID))
+	resp, err := c.GetRequest(
+		endpoint,
+		withAuth(c.ApiKey),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var webhooks Webhooks
+	if err := handleJSONResponse(resp, &webhooks, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &webhooks, nil
+}
+
+// CreateWebhooks creates webhooks and returns their IDs.
+// source: https://support.getgrist.com/api/#tag/webhooks/operation/postWebhooks
+func (d *Doc) CreateWebhooks(c *Client, fields ...WebhookFields) ([]string, error) {
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("CreateWebhooks: webhooks cannot be empty")
+	}
+
+	body := Webhooks{Webhooks: make([]Webhook, 0, len(fields))}
+	for _, f := range fields {
+		if f.URL == "" || f.TableID == "" || len(f.EventTypes) == 0 {
+			return nil, fmt.Errorf("CreateWebhooks: url, tableId and eventTypes are required")
+		}
+		body.Webhooks = append(body.Webhooks, Webhook{Fields: f})
+	}
+
+	endpoint := buildURL(c.ApiEndpoint(), pathWebhooks(d.ID))
+	jsonBody, err := withJSONBody(body)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.PostRequest(
+		endpoint,
+		withAuth(c.ApiKey),
+		jsonBody,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var created Webhooks
+	if err := handleJSONResponse(resp, &created, http.StatusOK); err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(created.Webhooks))
+	for _, w := range created.Webhooks {
+		ids = append(ids, w.ID)
+	}
+	return ids, nil
+}
+
+// ModifyWebhook updates the settings of a webhook.
+// source: https://support.getgrist.com/api/#tag/webhooks/operation/patchWebhook
+func (d *Doc) ModifyWebhook(c *Client, webhookID string, fields WebhookFields) error {
+	if webhookID == "" {
+		return fmt.Errorf("ModifyWebhook: webhook id cannot be empty")
+	}
+
+	endpoint := buildURL(c.ApiEndpoint(), pathWebhook(d.ID, webhookID))
+	jsonBody, err := withJSONBody(fields)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.PatchRequest(
+		endpoint,
+		withAuth(c.ApiKey),
+		jsonBody,
+	)
+	if err != nil {
+		return err
+	}
+	return handleStatus(resp, http.StatusOK)
+}
+
+// DeleteWebhook removes a webhook.
+// source: https://support.getgrist.com/api/#tag/webhooks/operation/deleteWebhook
+func (d *Doc) DeleteWebhook(c *Client, webhookID string) error {
+	if webhookID == "" {
+		return fmt.Errorf("DeleteWebhook: webhook id cannot be empty")
+	}
+
+	endpoint := buildURL(c.ApiEndpoint(), pathWebhook(d.ID, webhookID))
+	resp, err := c.DeleteRequest(
+		endpoint,
+		withAuth(c.ApiKey),
+	)
+	if err != nil {
+		return err
+	}
+	return handleStatus(resp, http.StatusOK)
+}
+
+// ClearWebhooksQueue drops the pending events of every webhook of the document.
+// source: https://support.getgrist.com/api/#tag/webhooks/operation/clearQueue
+func (d *Doc) ClearWebhooksQueue(c *Client) error {
+	endpoint := buildURL(c.ApiEndpoint(), pathWebhooksQueue(d.ID))
+	resp, err := c.DeleteRequest(
+		endpoint,
+		withAuth(c.ApiKey),
+	)
+	if err != nil {
+		return err
+	}
+	return handleStatus(resp, http.StatusOK)
+}
+
+// ClearWebhookQueue drops the pending events of a single webhook.
+func (d *Doc) ClearWebhookQueue(c *Client, webhookID string) error {
+	if webhookID == "" {
+		return fmt.Errorf("ClearWebhookQueue: webhook id cannot be empty")
+	}
+
+	endpoint := buildURL(c.ApiEndpoint(), pathWebhooksQueue(d.ID)+"/"+webhookID)
+	resp, err := c.DeleteRequest(
+		endpoint,
+		withAuth(c.ApiKey),
+	)
+	if err != nil {
+		return err
+	}
+	return handleStatus(resp, http.StatusOK)
+}
@@ -0,0 +1,141 @@
+package grist
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDoc_ListWebhooks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/api/docs/doc1/webhooks", r.URL.Path)
+		w.Write([]byte(`{"webhooks": [{
+			"id": "wh1",
+			"fields": {"name": "n8n", "url": "https://hooks.test", "enabled": true, "eventTypes": ["add"],
+				"isReadyColumn": "Ready", "tableId": "Pets", "unsubscribeKey": "k"},
+			"usage": {"numWaiting": 2, "status": "idle", "lastHttpStatus": 200,
+				"lastEventBatch": {"size": 1, "status": "success", "httpStatus": 200, "attempts": 1, "errorMessage": null}}
+		}, {
+			"id": "wh2",
+			"fields": {"url": "https://hooks.test", "eventTypes": ["update"], "isReadyColumn": null, "tableId": "Pets"}
+		}]}`))
+	}))
+	defer server.Close()
+
+	client, _ := NewGristClient(context.Background(), server.URL, "valid-key")
+	doc := &Doc{ID: "doc1"}
+	webhooks, err := doc.ListWebhooks(client)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	assert.Len(t, webhooks.Webhooks, 2)
+
+	wh := webhooks.Webhooks[0]
+	assert.Equal(t, "wh1", wh.ID)
+	assert.Equal(t, &ReadyColumn{ColID: "Ready"}, wh.Fields.IsReadyColumn)
+	assert.Equal(t, []WebhookEventType{WebhookEventAdd}, wh.Fields.EventTypes)
+	assert.Equal(t, 2, wh.Usage.NumWaiting)
+	assert.Equal(t, 200, *wh.Usage.LastHTTPStatus)
+	assert.Nil(t, wh.Usage.LastEventBatch.ErrorMessage)
+	assert.Nil(t, webhooks.Webhooks[1].Fields.IsReadyColumn)
+}
+
+func TestDoc_CreateWebhooks(t *testing.T) {
+	t.Run("Posts the webhooks and returns their ids", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "/api/docs/doc1/webhooks", r.URL.Path)
+			body, _ := io.ReadAll(r.Body)
+			assert.JSONEq(t, `{"webhooks": [{"fields": {
+				"url": "https://hooks.test", "eventTypes": ["add", "update"], "isReadyColumn": "Ready", "tableId": "Pets"
+			}}]}`, string(body))
+			w.Write([]byte(`{"webhooks": [{"id": "wh1"}]}`))
+		}))
+		defer server.Close()
+
+		client, _ := NewGristClient(context.Background(), server.URL, "valid-key")
+		doc := &Doc{ID: "doc1"}
+		ids, err := doc.CreateWebhooks(client, WebhookFields{
+			URL:           "https://hooks.test",
+			EventTypes:    []WebhookEventType{WebhookEventAdd, WebhookEventUpdate},
+			IsReadyColumn: &ReadyColumn{ColID: "Ready"},
+			TableID:       "Pets",
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		assert.Equal(t, []string{"wh1"}, ids)
+	})
+	t.Run("Without required fields returns error", func(t *testing.T) {
+		client, _ := NewGristClient(context.Background(), "https://getgrist.com", "valid-key")
+		doc := &Doc{ID: "doc1"}
+		_, err := doc.CreateWebhooks(client, WebhookFields{URL: "https://hooks.test"})
+		assert.Error(t, err)
+		_, err = doc.CreateWebhooks(client)
+		assert.Error(t, err)
+	})
+}
+
+func TestDoc_ModifyWebhook(t *testing.T) {
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPatch, r.Method)
+		assert.Equal(t, "/api/docs/doc1/webhooks/wh1", r.URL.Path)
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+	}))
+	defer server.Close()
+
+	client, _ := NewGristClient(context.Background(), server.URL, "valid-key")
+	doc := &Doc{ID: "doc1"}
+	enabled := false
+
+	t.Run("Sends only the set fields", func(t *testing.T) {
+		assert.NoError(t, doc.ModifyWebhook(client, "wh1", WebhookFields{Enabled: &enabled}))
+		assert.JSONEq(t, `{"enabled": false}`, body)
+	})
+	t.Run("With empty ready column clears it", func(t *testing.T) {
+		assert.NoError(t, doc.ModifyWebhook(client, "wh1", WebhookFields{IsReadyColumn: &ReadyColumn{}}))
+		assert.JSONEq(t, `{"isReadyColumn": null}`, body)
+	})
+	t.Run("Without id returns error", func(t *testing.T) {
+		assert.Error(t, doc.ModifyWebhook(client, "", WebhookFields{}))
+	})
+}
+
+func TestDoc_DeleteWebhook(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodDelete, r.Method)
+		assert.Equal(t, "/api/docs/doc1/webhooks/wh1", r.URL.Path)
+		w.Write([]byte(`{"success": true}`))
+	}))
+	defer server.Close()
+
+	client, _ := NewGristClient(context.Background(), server.URL, "valid-key")
+	doc := &Doc{ID: "doc1"}
+	assert.NoError(t, doc.DeleteWebhook(client, "wh1"))
+	assert.Error(t, doc.DeleteWebhook(client, ""))
+}
+
+func TestDoc_ClearWebhooksQueue(t *testing.T) {
+	var calls []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+	}))
+	defer server.Close()
+
+	client, _ := NewGristClient(context.Background(), server.URL, "valid-key")
+	doc := &Doc{ID: "doc1"}
+	assert.NoError(t, doc.ClearWebhooksQueue(client))
+	assert.NoError(t, doc.ClearWebhookQueue(client, "wh1"))
+	assert.Error(t, doc.ClearWebhookQueue(client, ""))
+	assert.Equal(t, []string{
+		"DELETE /api/docs/doc1/webhooks/queue",
+		"DELETE /api/docs/doc1/webhooks/queue/wh1",
+	}, calls)
+}
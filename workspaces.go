@@ -3,7 +3,9 @@ package grist
 import (
 	"fmt"
 	"net/http"
+	"net/url"
 	"strconv"
+	"time"
 )
 
 type Workspace struct {
@@ -13,6 +15,8 @@ type Workspace struct {
 	OrgDomain string     `json:"orgDomain,omitempty"`
 	Org       Org        `json:"org,omitempty"`
 	Docs      []Doc      `json:"docs,omitempty"`
+	// RemovedAt is set when the workspace is in the trash
+	RemovedAt *time.Time `json:"removedAt,omitempty"`
 }
 
 func pathOrgWorkspaces(orgID int64) string {
@@ -29,11 +33,27 @@ func pathWorkspaceDocs(wsID int64) string {
 
 // ListWorkspaces lists workspaces for an org.
 func ListWorkspaces(c *Client, orgId int64) ([]Workspace, error) {
+	return listWorkspaces(c, orgId, false)
+}
+
+// ListRemovedWorkspaces lists the trash of an org: removed workspaces, and
+// workspaces holding removed documents in their Docs.
+// source: https://support.getgrist.com/api/#tag/workspaces/operation/listWorkspaces
+func ListRemovedWorkspaces(c *Client, orgId int64) ([]Workspace, error) {
+	return listWorkspaces(c, orgId, true)
+}
+
+func listWorkspaces(c *Client, orgId int64, showRemoved bool) ([]Workspace, error) {
 	endpoint := buildURL(c.ApiEndpoint(), pathOrgWorkspaces(orgId))
+	values := url.Values{}
+	if showRemoved {
+		values.Set("showRemoved", "1")
+	}
 
 	resp, err := c.GetRequest(
 		endpoint,
 		withAuth(c.ApiKey),
+		withQuery(values),
 	)
 	if err != nil {
 		return nil, err
@@ -80,14 +100,45 @@ func CreateWorkspace(c *Client, orgId int64, name string) (*int64, error) {
 
 // DescribeWorkspace fetches a workspace by ID.
 func DescribeWorkspace(c *Client, wsId int64) (*Workspace, error) {
+	return describeWorkspace(c, wsId, false)
+}
+
+// ListRemovedDocs lists the documents of the workspace that are in the trash.
+// source: https://support.getgrist.com/api/#tag/workspaces/operation/describeWorkspace
+func (ws *Workspace) ListRemovedDocs(c *Client) ([]Doc, error) {
+	if ws == nil {
+		return nil, fmt.Errorf("invalid workspace receiver")
+	}
+
+	described, err := describeWorkspace(c, ws.ID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var removed []Doc
+	for _, doc := range described.Docs {
+		if doc.RemovedAt != nil {
+			removed = append(removed, doc)
+		}
+	}
+	return removed, nil
+}
+
+func describeWorkspace(c *Client, wsId int64, showRemoved bool) (*Workspace, error) {
 	if wsId <= 0 {
 		return nil, fmt.Errorf("invalid wsId: %d", wsId)
 	}
 
 	endpoint := buildURL(c.ApiEndpoint(), pathWorkspace(wsId))
+	values := url.Values{}
+	if showRemoved {
+		values.Set("showRemoved", "1")
+	}
+
 	resp, err := c.GetRequest(
 		endpoint,
 		withAuth(c.ApiKey),
+		withQuery(values),
 	)
 	if err != nil {
 		return nil, err
@@ -149,3 +200,34 @@ func (ws *Workspace) Delete(c *Client) error {
 	}
 	return nil
 }
+
+// Remove moves the workspace and its documents to the trash, see Unremove.
+// source: https://support.getgrist.com/api/#tag/workspaces/operation/removeWorkspace
+func (ws *Workspace) Remove(c *Client) error {
+	if ws == nil || ws.ID <= 0 {
+		return fmt.Errorf("invalid workspace receiver")
+	}
+	return trashRequest(c, pathWorkspace(ws.ID)+"/remove")
+}
+
+// Unremove restores the workspace from the trash.
+// source: https://support.getgrist.com/api/#tag/workspaces/operation/unremoveWorkspace
+func (ws *Workspace) Unremove(c *Client) error {
+	if ws == nil || ws.ID <= 0 {
+		return fmt.Errorf("invalid workspace receiver")
+	}
+	return trashRequest(c, pathWorkspace(ws.ID)+"/unremove")
+}
+
+// trashRequest posts to a remove or unremove endpoint
+func trashRequest(c *Client, path string) error {
+	endpoint := buildURL(c.ApiEndpoint(), path)
+	resp, err := c.PostRequest(
+		endpoint,
+		withAuth(c.ApiKey),
+	)
+	if err != nil {
+		return err
+	}
+	return handleStatus(resp, http.StatusOK)
+}
@@ -0,0 +1,118 @@
+package grist
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListWorkspaces(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/orgs/2/workspaces", r.URL.Path)
+		assert.False(t, r.URL.Query().Has("showRemoved"))
+		w.Write([]byte(`[{"id":3,"name":"Home"}]`))
+	}))
+	defer server.Close()
+
+	client, _ := NewGristClient(context.Background(), server.URL, "valid-key")
+	workspaces, err := ListWorkspaces(client, 2)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	assert.Len(t, workspaces, 1)
+	assert.Nil(t, workspaces[0].RemovedAt)
+}
+
+func TestListRemovedWorkspaces(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/orgs/2/workspaces", r.URL.Path)
+		assert.Equal(t, "1", r.URL.Query().Get("showRemoved"))
+		w.Write([]byte(`[
+			{"id":3,"name":"Old","removedAt":"2024-05-01T10:00:00.000Z"},
+			{"id":4,"name":"Home","docs":[{"id":"doc1","name":"Pets","removedAt":"2024-05-02T10:00:00.000Z"}]}
+		]`))
+	}))
+	defer server.Close()
+
+	client, _ := NewGristClient(context.Background(), server.URL, "valid-key")
+	workspaces, err := ListRemovedWorkspaces(client, 2)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	assert.Len(t, workspaces, 2)
+	if assert.NotNil(t, workspaces[0].RemovedAt) {
+		assert.Equal(t, 2024, workspaces[0].RemovedAt.Year())
+	}
+	assert.Nil(t, workspaces[1].RemovedAt)
+	assert.NotNil(t, workspaces[1].Docs[0].RemovedAt)
+}
+
+func TestWorkspace_ListRemovedDocs(t *testing.T) {
+	t.Run("Returns only the removed documents", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/api/workspaces/3", r.URL.Path)
+			assert.Equal(t, "1", r.URL.Query().Get("showRemoved"))
+			w.Write([]byte(`{"id":3,"name":"Home","docs":[
+				{"id":"doc1","name":"Pets","removedAt":"2024-05-02T10:00:00.000Z"},
+				{"id":"doc2","name":"Owners"}
+			]}`))
+		}))
+		defer server.Close()
+
+		client, _ := NewGristClient(context.Background(), server.URL, "valid-key")
+		ws := &Workspace{ID: 3}
+		docs, err := ws.ListRemovedDocs(client)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if assert.Len(t, docs, 1) {
+			assert.Equal(t, "doc1", docs[0].ID)
+			assert.Equal(t, 2024, docs[0].RemovedAt.Year())
+		}
+	})
+	t.Run("DescribeWorkspace hides removed documents", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/api/workspaces/3", r.URL.Path)
+			assert.False(t, r.URL.Query().Has("showRemoved"))
+			w.Write([]byte(`{"id":3,"name":"Home","docs":[{"id":"doc2","name":"Owners"}]}`))
+		}))
+		defer server.Close()
+
+		client, _ := NewGristClient(context.Background(), server.URL, "valid-key")
+		ws, err := DescribeWorkspace(client, 3)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		assert.Len(t, ws.Docs, 1)
+	})
+}
+
+func TestWorkspace_RemoveAndUnremove(t *testing.T) {
+	var calls []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+	}))
+	defer server.Close()
+
+	client, _ := NewGristClient(context.Background(), server.URL, "valid-key")
+	ws := &Workspace{ID: 3}
+	assert.NoError(t, ws.Remove(client))
+	assert.NoError(t, ws.Unremove(client))
+	assert.Equal(t, []string{"POST /api/workspaces/3/remove", "POST /api/workspaces/3/unremove"}, calls)
+
+	assert.Error(t, (&Workspace{}).Remove(client))
+}
+
+func TestTrashRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	client, _ := NewGristClient(context.Background(), server.URL, "valid-key")
+	err := trashRequest(client, "/workspaces/3/remove")
+	assert.ErrorIs(t, err, ErrForbidden)
+}